@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// requestIDHeader is the header the Liara API identifies its responses
+// with, support asks for it when investigating a failed request.
+const requestIDHeader = "X-Request-Id"
+
+// apiError is the error envelope of the Liara API.
+type apiError struct {
+	Message string            `json:"message"`
+	Errors  []json.RawMessage `json:"errors"`
+}
+
+// apiFieldError is an error of a single field of a request.
+type apiFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// responseErrorMessage formats the body of a failed API response like
+// apiErrorMessage, followed by the request id of the response if it has one.
+func responseErrorMessage(response *http.Response, body []byte) string {
+	message := apiErrorMessage(body)
+
+	if requestID := response.Header.Get(requestIDHeader); len(requestID) > 0 {
+		message = fmt.Sprintf("%s (request id: %s)", message, requestID)
+	}
+
+	return message
+}
+
+// apiErrorMessage formats the body of a failed API response as a readable
+// message, with one line per field error. The raw body is returned when it
+// isn't the Liara error envelope.
+func apiErrorMessage(body []byte) string {
+	raw := strings.TrimSpace(string(body))
+
+	var envelope apiError
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return raw
+	}
+
+	lines := make([]string, 0, len(envelope.Errors)+1)
+	if len(envelope.Message) > 0 {
+		lines = append(lines, envelope.Message)
+	}
+
+	for _, rawError := range envelope.Errors {
+		var message string
+		if err := json.Unmarshal(rawError, &message); err == nil {
+			lines = append(lines, "- "+message)
+			continue
+		}
+
+		var fieldError apiFieldError
+		if err := json.Unmarshal(rawError, &fieldError); err != nil || len(fieldError.Message) == 0 {
+			lines = append(lines, "- "+string(rawError))
+			continue
+		}
+
+		if len(fieldError.Field) > 0 {
+			lines = append(lines, "- "+fieldError.Field+": "+fieldError.Message)
+		} else {
+			lines = append(lines, "- "+fieldError.Message)
+		}
+	}
+
+	if len(lines) == 0 {
+		return raw
+	}
+
+	return strings.Join(lines, "\n")
+}
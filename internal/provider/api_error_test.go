@@ -0,0 +1,45 @@
+package provider
+
+import "testing"
+
+func TestAPIErrorMessage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "message",
+			body:     `{"statusCode":404,"error":"Not Found","message":"Project not found."}`,
+			expected: "Project not found.",
+		},
+		{
+			name:     "message with field errors",
+			body:     `{"message":"Invalid request.","errors":[{"field":"planID","message":"plan not found"},{"message":"name is taken"},"platform is required"]}`,
+			expected: "Invalid request.\n- planID: plan not found\n- name is taken\n- platform is required",
+		},
+		{
+			name:     "envelope without a message",
+			body:     `{"statusCode":500}`,
+			expected: `{"statusCode":500}`,
+		},
+		{
+			name:     "non-JSON body",
+			body:     "<html>502 Bad Gateway</html>\n",
+			expected: "<html>502 Bad Gateway</html>",
+		},
+		{
+			name:     "JSON array body",
+			body:     `["unexpected"]`,
+			expected: `["unexpected"]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if message := apiErrorMessage([]byte(tc.body)); message != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &APIStatusDataSource{}
+
+func NewAPIStatusDataSource() datasource.DataSource {
+	return &APIStatusDataSource{}
+}
+
+// APIStatusDataSource defines the data source implementation.
+type APIStatusDataSource struct {
+	client paas.ClientInterface
+}
+
+// APIStatusDataSourceModel describes the data source data model.
+type APIStatusDataSourceModel struct {
+	StatusCode         types.Int64 `tfsdk:"status_code"`
+	RateLimitLimit     types.Int64 `tfsdk:"rate_limit_limit"`
+	RateLimitRemaining types.Int64 `tfsdk:"rate_limit_remaining"`
+	RateLimitReset     types.Int64 `tfsdk:"rate_limit_reset"`
+}
+
+func (d *APIStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_status"
+}
+
+func (d *APIStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "API status data source, useful for checking the rate-limit headroom before large applies. The API has no dedicated status endpoint, so the probe lists the apps of the account, which uses one request of the rate limit and returns a response that grows with the number of apps",
+
+		Attributes: map[string]schema.Attribute{
+			"status_code": schema.Int64Attribute{
+				MarkdownDescription: "status code of the probe request, which lists the apps of the account",
+				Computed:            true,
+			},
+			"rate_limit_limit": schema.Int64Attribute{
+				MarkdownDescription: "value of the `X-RateLimit-Limit` header (null if not sent by the API)",
+				Computed:            true,
+			},
+			"rate_limit_remaining": schema.Int64Attribute{
+				MarkdownDescription: "value of the `X-RateLimit-Remaining` header (null if not sent by the API)",
+				Computed:            true,
+			},
+			"rate_limit_reset": schema.Int64Attribute{
+				MarkdownDescription: "value of the `X-RateLimit-Reset` header (null if not sent by the API)",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *APIStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.PaasClient
+}
+
+func (d *APIStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data APIStatusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the apps listing is the only authenticated endpoint without
+	// parameters, its cost is documented in the schema.
+	response, err := d.client.GetApps(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Reading API status failed", fmt.Sprintf("Unable to reach the API, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			resp.Diagnostics.AddError("reading response payload failed", err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Reading API status failed", fmt.Sprintf("Unable to read API status, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	data.StatusCode = types.Int64Value(int64(response.StatusCode))
+	data.RateLimitLimit = headerInt64(response.Header, "X-RateLimit-Limit")
+	data.RateLimitRemaining = headerInt64(response.Header, "X-RateLimit-Remaining")
+	data.RateLimitReset = headerInt64(response.Header, "X-RateLimit-Reset")
+
+	tflog.Trace(ctx, "read api status data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// headerInt64 returns the integer value of the given header, or null when
+// the header is missing or isn't a valid integer.
+func headerInt64(header http.Header, key string) types.Int64 {
+	value, err := strconv.ParseInt(header.Get(key), 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(value)
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+func TestAPIStatusDataSourceRead(t *testing.T) {
+	testCases := []struct {
+		name      string
+		headers   map[string]string
+		limit     *int64
+		remaining *int64
+		reset     *int64
+	}{
+		{
+			name: "headers present",
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "42",
+				"X-RateLimit-Reset":     "1700000000",
+			},
+			limit:     ptr(int64(100)),
+			remaining: ptr(int64(42)),
+			reset:     ptr(int64(1700000000)),
+		},
+		{
+			name:    "headers missing",
+			headers: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tc.headers {
+					w.Header().Set(key, value)
+				}
+				_, _ = w.Write([]byte(`{"projects":[]}`))
+			}))
+			defer server.Close()
+
+			client, err := paas.NewClient(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			d := &APIStatusDataSource{client: client}
+
+			schemaResp := datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw:    testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+
+			d.Read(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data APIStatusDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if data.StatusCode.ValueInt64() != http.StatusOK {
+				t.Errorf("expected status code %d, got %d", http.StatusOK, data.StatusCode.ValueInt64())
+			}
+
+			assertInt64(t, "rate_limit_limit", data.RateLimitLimit.ValueInt64Pointer(), tc.limit)
+			assertInt64(t, "rate_limit_remaining", data.RateLimitRemaining.ValueInt64Pointer(), tc.remaining)
+			assertInt64(t, "rate_limit_reset", data.RateLimitReset.ValueInt64Pointer(), tc.reset)
+		})
+	}
+}
+
+func assertInt64(t *testing.T, name string, got, want *int64) {
+	t.Helper()
+
+	switch {
+	case want == nil && got != nil:
+		t.Errorf("expected %s to be null, got %d", name, *got)
+	case want != nil && got == nil:
+		t.Errorf("expected %s to be %d, got null", name, *want)
+	case want != nil && *got != *want:
+		t.Errorf("expected %s to be %d, got %d", name, *want, *got)
+	}
+}
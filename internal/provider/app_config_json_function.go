@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// sensitiveAppAttributes lists the app attributes which are left out of the
+// exported configuration unless explicitly requested.
+var sensitiveAppAttributes = []string{
+	"secret_envs",
+	"secret_envs_wo",
+}
+
+// sensitiveAppDataSourceAttributes lists the attributes of the liara_app data
+// source which are left out as well. The data source has no secret_envs, so
+// its envs hold the secret envs too.
+var sensitiveAppDataSourceAttributes = []string{
+	"envs",
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &AppConfigJSONFunction{}
+
+func NewAppConfigJSONFunction() function.Function {
+	return &AppConfigJSONFunction{}
+}
+
+// AppConfigJSONFunction defines the function implementation.
+type AppConfigJSONFunction struct{}
+
+func (f *AppConfigJSONFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "app_config_json"
+}
+
+func (f *AppConfigJSONFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Export an app configuration as JSON",
+		MarkdownDescription: "Serializes the resolved attributes of a `liara_app` data source (or resource) into a normalized JSON document. Sensitive attributes, `secret_envs` of the resource and `envs` of the data source, are omitted unless `include_sensitive` is set to `true`.",
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "app",
+				MarkdownDescription: "app object, e.g. `data.liara_app.example`",
+			},
+		},
+		VariadicParameter: function.BoolParameter{
+			Name:                "include_sensitive",
+			MarkdownDescription: "include sensitive attributes in the output (default: false)",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *AppConfigJSONFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var app types.Dynamic
+	var includeSensitive []bool
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &app, &includeSensitive))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(includeSensitive) > 1 {
+		resp.Error = function.NewArgumentFuncError(1, "include_sensitive can be passed at most once")
+		return
+	}
+
+	object, ok := app.UnderlyingValue().(basetypes.ObjectValue)
+	if !ok || object.IsNull() {
+		resp.Error = function.NewArgumentFuncError(0, "app must be a non-null object")
+		return
+	}
+
+	attributes := make(map[string]attr.Value, len(object.Attributes()))
+	for name, value := range object.Attributes() {
+		attributes[name] = value
+	}
+
+	if len(includeSensitive) == 0 || !includeSensitive[0] {
+		sensitiveAttributes := sensitiveAppAttributes
+		if _, isResource := attributes["secret_envs"]; !isResource {
+			sensitiveAttributes = slices.Concat(sensitiveAppAttributes, sensitiveAppDataSourceAttributes)
+		}
+
+		for _, name := range sensitiveAttributes {
+			delete(attributes, name)
+		}
+	}
+
+	document := make(map[string]any, len(attributes))
+	for name, value := range attributes {
+		converted, err := attrValueToJSON(value)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unable to serialize %q: %s", name, err))
+			return
+		}
+
+		document[name] = converted
+	}
+
+	// map keys are sorted by encoding/json, which keeps the output stable.
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("unable to encode app configuration: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(encoded)))
+}
+
+// attrValueToJSON converts a framework value into a value which can be
+// marshalled by encoding/json.
+func attrValueToJSON(value attr.Value) (any, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value is not known yet")
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return attrValueToJSON(v.UnderlyingValue())
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.Int64Value:
+		return v.ValueInt64(), nil
+	case basetypes.Int32Value:
+		return v.ValueInt32(), nil
+	case basetypes.Float64Value:
+		return v.ValueFloat64(), nil
+	case basetypes.Float32Value:
+		return v.ValueFloat32(), nil
+	case basetypes.NumberValue:
+		return json.Number(v.ValueBigFloat().Text('g', -1)), nil
+	case basetypes.ObjectValue:
+		return attrValuesToJSONObject(v.Attributes())
+	case basetypes.MapValue:
+		return attrValuesToJSONObject(v.Elements())
+	case basetypes.ListValue:
+		return attrValuesToJSONArray(v.Elements())
+	case basetypes.SetValue:
+		return attrValuesToJSONArray(v.Elements())
+	case basetypes.TupleValue:
+		return attrValuesToJSONArray(v.Elements())
+	}
+
+	return nil, fmt.Errorf("unsupported value type %T", value)
+}
+
+func attrValuesToJSONObject(values map[string]attr.Value) (map[string]any, error) {
+	result := make(map[string]any, len(values))
+	for key, value := range values {
+		converted, err := attrValueToJSON(value)
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = converted
+	}
+
+	return result, nil
+}
+
+func attrValuesToJSONArray(values []attr.Value) ([]any, error) {
+	result := make([]any, 0, len(values))
+	for _, value := range values {
+		converted, err := attrValueToJSON(value)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, converted)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAppConfigJSONFunctionRunResource(t *testing.T) {
+	app := types.ObjectValueMust(
+		map[string]attr.Type{
+			"name":           types.StringType,
+			"envs":           types.MapType{ElemType: types.StringType},
+			"secret_envs":    types.MapType{ElemType: types.StringType},
+			"secret_envs_wo": types.MapType{ElemType: types.StringType},
+		},
+		map[string]attr.Value{
+			"name": types.StringValue("my-app"),
+			"envs": types.MapValueMust(types.StringType, map[string]attr.Value{
+				"PORT": types.StringValue("8080"),
+			}),
+			"secret_envs": types.MapValueMust(types.StringType, map[string]attr.Value{
+				"SECRET": types.StringValue("s3cr3t"),
+			}),
+			"secret_envs_wo": types.MapNull(types.StringType),
+		},
+	)
+
+	testCases := map[bool]string{
+		false: `{"envs":{"PORT":"8080"},"name":"my-app"}`,
+		true:  `{"envs":{"PORT":"8080"},"name":"my-app","secret_envs":{"SECRET":"s3cr3t"},"secret_envs_wo":null}`,
+	}
+
+	for includeSensitive, expected := range testCases {
+		req := function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{
+				types.DynamicValue(app),
+				types.TupleValueMust([]attr.Type{types.BoolType}, []attr.Value{types.BoolValue(includeSensitive)}),
+			}),
+		}
+		resp := function.RunResponse{
+			Result: function.NewResultData(types.StringUnknown()),
+		}
+
+		(&AppConfigJSONFunction{}).Run(context.Background(), req, &resp)
+		if resp.Error != nil {
+			t.Fatalf("include_sensitive %t: unexpected error: %s", includeSensitive, resp.Error)
+		}
+
+		if result := resp.Result.Value().(types.String).ValueString(); result != expected {
+			t.Errorf("include_sensitive %t: expected %s, got %s", includeSensitive, expected, result)
+		}
+	}
+}
+
+func TestAppConfigJSONFunctionRun(t *testing.T) {
+	app := types.ObjectValueMust(
+		map[string]attr.Type{
+			"name":     types.StringType,
+			"plan_id":  types.StringType,
+			"turn_off": types.BoolType,
+			"envs":     types.MapType{ElemType: types.StringType},
+		},
+		map[string]attr.Value{
+			"name":     types.StringValue("my-app"),
+			"plan_id":  types.StringValue("small"),
+			"turn_off": types.BoolValue(false),
+			"envs": types.MapValueMust(types.StringType, map[string]attr.Value{
+				"SECRET": types.StringValue("s3cr3t"),
+			}),
+		},
+	)
+
+	testCases := []struct {
+		name             string
+		includeSensitive []attr.Value
+		expected         string
+	}{
+		{
+			name:     "sensitive values omitted by default",
+			expected: `{"name":"my-app","plan_id":"small","turn_off":false}`,
+		},
+		{
+			name:             "sensitive values omitted when not requested",
+			includeSensitive: []attr.Value{types.BoolValue(false)},
+			expected:         `{"name":"my-app","plan_id":"small","turn_off":false}`,
+		},
+		{
+			name:             "sensitive values included when requested",
+			includeSensitive: []attr.Value{types.BoolValue(true)},
+			expected:         `{"envs":{"SECRET":"s3cr3t"},"name":"my-app","plan_id":"small","turn_off":false}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			elementTypes := make([]attr.Type, len(tc.includeSensitive))
+			for i := range elementTypes {
+				elementTypes[i] = types.BoolType
+			}
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.DynamicValue(app),
+					types.TupleValueMust(elementTypes, tc.includeSensitive),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			(&AppConfigJSONFunction{}).Run(ctx, req, &resp)
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			result, ok := resp.Result.Value().(types.String)
+			if !ok {
+				t.Fatalf("unexpected result type %T", resp.Result.Value())
+			}
+
+			if result.ValueString() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, result.ValueString())
+			}
+		})
+	}
+}
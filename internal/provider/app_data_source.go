@@ -2,10 +2,7 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -37,12 +34,17 @@ type AppDataSourceModel struct {
 	ReadOnlyRootFilesystem types.Bool   `tfsdk:"read_only_root_filesystem"`
 	NetworkName            types.String `tfsdk:"network_name"`
 
-	RollingUpdate           types.Bool   `tfsdk:"rolling_update"`
+	ZeroDowntime            types.Bool   `tfsdk:"zero_downtime"`
 	TurnOff                 types.Bool   `tfsdk:"turn_off"`
 	Envs                    types.Map    `tfsdk:"envs"`
 	StaticIP                types.String `tfsdk:"static_ip"`
 	EnableStaticIP          types.Bool   `tfsdk:"enable_static_ip"`
 	DisableDefaultSubDomain types.Bool   `tfsdk:"disable_default_subdomain"`
+
+	HourlyPrice types.Float64 `tfsdk:"hourly_price"`
+	IsDeployed  types.Bool    `tfsdk:"is_deployed"`
+	Status      types.String  `tfsdk:"status"`
+	CreatedAt   types.String  `tfsdk:"created_at"`
 }
 
 func (d *AppDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,7 +54,7 @@ func (d *AppDataSource) Metadata(ctx context.Context, req datasource.MetadataReq
 func (d *AppDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "App data source",
+		MarkdownDescription: "App data source, looks an app up by its name",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -60,54 +62,70 @@ func (d *AppDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 				MarkdownDescription: "identifier",
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "name",
+				MarkdownDescription: "name of the app to look up",
 				Required:            true,
 			},
 			"plan_id": schema.StringAttribute{
 				MarkdownDescription: "plan id",
-				Required:            true,
+				Computed:            true,
 			},
 			"bundle_plan_id": schema.StringAttribute{
 				MarkdownDescription: "bundle plan id",
-				Optional:            true,
+				Computed:            true,
 			},
 			"platform": schema.StringAttribute{
 				MarkdownDescription: "platform",
-				Required:            true,
+				Computed:            true,
 			},
 			"read_only_root_filesystem": schema.BoolAttribute{
 				MarkdownDescription: "read only root filesystem",
-				Required:            true,
+				Computed:            true,
 			},
 			"network_name": schema.StringAttribute{
 				MarkdownDescription: "network name",
-				Optional:            true,
+				Computed:            true,
 			},
-			"rolling_update": schema.BoolAttribute{
-				MarkdownDescription: "rolling update",
-				Optional:            true,
+			"zero_downtime": schema.BoolAttribute{
+				MarkdownDescription: "whether the app is deployed and restarted without downtime",
+				Computed:            true,
 			},
 			"turn_off": schema.BoolAttribute{
 				MarkdownDescription: "is the app should be turned off or not (true for turn off, false for turning on)",
-				Optional:            true,
+				Computed:            true,
 			},
 			"envs": schema.MapAttribute{
 				MarkdownDescription: "environment variables",
-				Optional:            true,
+				Computed:            true,
 				ElementType:         types.StringType,
 				Sensitive:           true,
 			},
 			"static_ip": schema.StringAttribute{
 				MarkdownDescription: "static ip",
-				Optional:            true,
+				Computed:            true,
 			},
 			"enable_static_ip": schema.BoolAttribute{
 				MarkdownDescription: "enable static ip",
-				Optional:            true,
+				Computed:            true,
 			},
 			"disable_default_subdomain": schema.BoolAttribute{
 				MarkdownDescription: "disable default subdomain",
-				Optional:            true,
+				Computed:            true,
+			},
+			"hourly_price": schema.Float64Attribute{
+				MarkdownDescription: "hourly price",
+				Computed:            true,
+			},
+			"is_deployed": schema.BoolAttribute{
+				MarkdownDescription: "whether the app has been deployed",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "app status",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "creation time",
+				Computed:            true,
 			},
 		},
 	}
@@ -129,24 +147,7 @@ func (d *AppDataSource) Configure(ctx context.Context, req datasource.ConfigureR
 		return
 	}
 
-	paasClient, err := paas.NewClient(
-		providerData.APIEndpoint,
-		paas.WithHTTPClient(providerData.HTTPClient),
-		paas.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", providerData.AccessToken))
-			return nil
-		}),
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create PAAS client",
-			fmt.Sprintf("Expected paas.ClientInterface, got: %T. Please report this issue to the provider developers.", err),
-		)
-
-		return
-	}
-
-	d.client = paasClient
+	d.client = providerData.PaasClient
 }
 
 func (d *AppDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -159,85 +160,38 @@ func (d *AppDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
-	response, err := d.client.GetAppByName(ctx, data.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Reading App info failed", fmt.Sprintf("Unable to read app info, got error: %s", err))
-		return
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(response.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("reading response payload failed", err.Error())
-
-			return
-		}
-
-		resp.Diagnostics.AddError("Reading App info failed", fmt.Sprintf("Unable to read app info, got error: %s", string(body)))
-		return
-	}
-
-	responseModel := struct {
-		Project struct {
-			ID                     string `json:"_id"`
-			ProjectID              string `json:"project_id"`
-			Type                   string `json:"type"`
-			Status                 string `json:"status"`
-			DefaultSubdomain       bool   `json:"defaultSubdomain"`
-			ReadOnlyRootFilesystem bool   `json:"readOnlyRootFilesystem"`
-			ZeroDowntime           bool   `json:"zeroDowntime"`
-			Scale                  int    `json:"scale"`
-			Envs                   []struct {
-				Key       string `json:"key"`
-				Value     string `json:"value"`
-				Encrypted bool   `json:"encrypted"`
-			} `json:"envs"`
-			PlanID       string `json:"planID"`
-			BundlePlanID string `json:"bundlePlanID"`
-			Network      struct {
-				ID   string `json:"_id"`
-				Name string `json:"name"`
-			} `json:"network"`
-			FixedIPStatus string `json:"fixedIPStatus"`
-			CreatedAt     string `json:"created_at"`
-			Node          struct {
-				ID string `json:"_id"`
-				IP string `json:"IP"`
-			} `json:"node"`
-			HourlyPrice       int  `json:"hourlyPrice"`
-			IsDeployed        bool `json:"isDeployed"`
-			ReservedDiskSpace int  `json:"reservedDiskSpace"`
-		} `json:"project"`
-	}{}
-
-	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
-		resp.Diagnostics.AddError("Decoding read response failed", fmt.Sprintf("Unable to decode read response, got error: %s", err))
+	app := getApp(ctx, d.client, data.Name.ValueString(), &resp.Diagnostics)
+	if app == nil {
 		return
 	}
 
 	envs := make(map[string]attr.Value)
-	for _, env := range responseModel.Project.Envs {
+	for _, env := range app.Project.Envs {
 		envs[env.Key] = types.StringValue(env.Value)
 	}
 
-	data.ID = types.StringValue(responseModel.Project.ID)
-	data.Name = types.StringValue(responseModel.Project.ID)
-	data.PlanID = types.StringValue(responseModel.Project.PlanID)
-	data.BundlePlanID = types.StringValue(responseModel.Project.BundlePlanID)
-	data.Platform = types.StringValue(responseModel.Project.Type)
-	data.ReadOnlyRootFilesystem = types.BoolValue(responseModel.Project.ReadOnlyRootFilesystem)
-	data.NetworkName = types.StringValue(responseModel.Project.Network.Name)
-	data.RollingUpdate = types.BoolValue(responseModel.Project.ZeroDowntime)
-	data.TurnOff = types.BoolValue(responseModel.Project.Scale == 0)
+	data.ID = types.StringValue(app.Project.ID)
+	data.Name = types.StringValue(app.Project.ProjectID)
+	data.PlanID = types.StringValue(app.Project.PlanID)
+	data.BundlePlanID = types.StringValue(app.Project.BundlePlanID)
+	data.Platform = types.StringValue(app.Project.Type)
+	data.ReadOnlyRootFilesystem = types.BoolValue(app.Project.ReadOnlyRootFilesystem)
+	data.NetworkName = types.StringValue(app.Project.Network.name())
+	data.ZeroDowntime = types.BoolValue(app.Project.ZeroDowntime)
+	data.TurnOff = types.BoolValue(app.Project.Scale == 0)
 	data.Envs = types.MapValueMust(types.StringType, envs)
 
-	data.EnableStaticIP = types.BoolValue(len(responseModel.Project.Node.IP) > 0)
+	data.EnableStaticIP = types.BoolValue(len(app.Project.Node.ip()) > 0)
 	if data.EnableStaticIP.ValueBool() {
-		data.StaticIP = types.StringValue(responseModel.Project.Node.IP)
+		data.StaticIP = types.StringValue(app.Project.Node.ip())
 	}
 
-	data.DisableDefaultSubDomain = types.BoolValue(!responseModel.Project.DefaultSubdomain)
+	data.DisableDefaultSubDomain = types.BoolValue(!app.Project.DefaultSubdomain)
+
+	data.HourlyPrice = types.Float64Value(app.Project.HourlyPrice)
+	data.IsDeployed = types.BoolValue(app.Project.IsDeployed)
+	data.Status = types.StringValue(app.Project.Status)
+	data.CreatedAt = types.StringValue(app.Project.CreatedAt)
 
 	tflog.Trace(ctx, "read app data source")
 
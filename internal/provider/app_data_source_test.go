@@ -1,12 +1,19 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
 )
 
 func TestAccExampleDataSource(t *testing.T) {
@@ -34,3 +41,180 @@ data "liara_app" "test" {
   configurable_attribute = "example"
 }
 `
+
+func TestAppDataSourceReadByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/my-app" {
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+		}
+
+		_, _ = w.Write([]byte(`{"project":{
+			"_id":"id","project_id":"my-app","type":"docker","planID":"small","readOnlyRootFilesystem":true,
+			"scale":1,"status":"RUNNING","isDeployed":true,"hourlyPrice":12.5,"created_at":"2024-01-02T03:04:05.000Z",
+			"envs":[{"key":"PORT","value":"8080"}]
+		}}`))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	d := &AppDataSource{client: client}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "my-app"),
+	})
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+	}
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	d.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data AppDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if data.ID.ValueString() != "id" {
+		t.Errorf("expected id to be id, got %s", data.ID)
+	}
+
+	if data.PlanID.ValueString() != "small" {
+		t.Errorf("expected plan_id small, got %s", data.PlanID)
+	}
+
+	if data.Platform.ValueString() != "docker" {
+		t.Errorf("expected platform docker, got %s", data.Platform)
+	}
+
+	if !data.ReadOnlyRootFilesystem.ValueBool() {
+		t.Errorf("expected read_only_root_filesystem to be true, got %s", data.ReadOnlyRootFilesystem)
+	}
+
+	if data.TurnOff.ValueBool() {
+		t.Errorf("expected turn_off to be false, got %s", data.TurnOff)
+	}
+
+	if port, ok := data.Envs.Elements()["PORT"]; !ok || port.String() != `"8080"` {
+		t.Errorf("expected the PORT env to be 8080, got %s", data.Envs)
+	}
+
+	if data.Status.ValueString() != "RUNNING" {
+		t.Errorf("expected status RUNNING, got %s", data.Status)
+	}
+}
+
+func TestAppDataSourceSchemaOnlyNameRequired(t *testing.T) {
+	ctx := context.Background()
+	d := &AppDataSource{}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	for name, attribute := range schemaResp.Schema.Attributes {
+		if name == "name" {
+			if !attribute.IsRequired() {
+				t.Error("expected name to be required")
+			}
+
+			continue
+		}
+
+		if attribute.IsRequired() || attribute.IsOptional() || !attribute.IsComputed() {
+			t.Errorf("expected %s to only be computed", name)
+		}
+	}
+}
+
+func TestAppDataSourceReadErrorRequestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects/traced-app" {
+			w.Header().Set("X-Request-Id", "abc123")
+		}
+
+		if r.URL.Path == "/v1/projects/missing-app" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message":"Internal server error."}`))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	d := &AppDataSource{client: client}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	testCases := []struct {
+		name     string
+		app      string
+		expected string
+	}{
+		{
+			name:     "with request id",
+			app:      "traced-app",
+			expected: "Unable to read app info, got error: Internal server error. (request id: abc123)",
+		},
+		{
+			name:     "without request id",
+			app:      "my-app",
+			expected: "Unable to read app info, got error: Internal server error.",
+		},
+		{
+			name:     "not found",
+			app:      "missing-app",
+			expected: `App "missing-app" does not exist`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw: testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+						"name": tftypes.NewValue(tftypes.String, tc.app),
+					}),
+				},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+
+			d.Read(ctx, req, &resp)
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got: %v", resp.Diagnostics)
+			}
+
+			if detail := resp.Diagnostics.Errors()[0].Detail(); detail != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, detail)
+			}
+		})
+	}
+}
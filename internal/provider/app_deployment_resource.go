@@ -0,0 +1,444 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+// appDeploymentPollInterval is the wait between two checks of a release.
+var appDeploymentPollInterval = 5 * time.Second
+
+// appDeploymentLogTailLines is the number of log lines shown when a
+// deployment fails.
+const appDeploymentLogTailLines = 20
+
+// appReleaseSucceededState is the state of a release which is deployed.
+const appReleaseSucceededState = "READY"
+
+// appReleaseFailedStates lists the states of a release which won't be
+// deployed.
+var appReleaseFailedStates = []string{
+	"FAILED",
+	"CANCELED",
+}
+
+// appDeploymentTimeoutsOpts selects the operations of the timeouts block,
+// they default to the provider timeout.
+var appDeploymentTimeoutsOpts = timeouts.Opts{
+	Create:            true,
+	Update:            true,
+	CreateDescription: "maximum duration of the first deployment, including the build, e.g. `30m` (default: the provider timeout)",
+	UpdateDescription: "maximum duration of a new deployment, including the build, e.g. `30m` (default: the provider timeout)",
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AppDeploymentResource{}
+
+func NewAppDeploymentResource() resource.Resource {
+	return &AppDeploymentResource{}
+}
+
+// AppDeploymentResource defines the resource implementation.
+type AppDeploymentResource struct {
+	client  paas.ClientInterface
+	timeout time.Duration
+	clock   clock
+}
+
+// AppDeploymentResourceModel describes the resource data model.
+type AppDeploymentResourceModel struct {
+	AppName      types.String `tfsdk:"app_name"`
+	SourceRef    types.String `tfsdk:"source_ref"`
+	Port         types.Int64  `tfsdk:"port"`
+	DeploymentID types.String `tfsdk:"deployment_id"`
+	Status       types.String `tfsdk:"status"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// appReleaseModel is a release of an app, as listed by the API.
+type appReleaseModel struct {
+	ID        string `json:"_id"`
+	State     string `json:"state"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func (r *AppDeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_deployment"
+}
+
+func (r *AppDeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "App deployment resource, deploys an uploaded source to an app, separately from the app configuration. " +
+			"A new deployment is made whenever the source or the port changes, and waited for until it's deployed or fails. Deployments are kept when the resource is destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"app_name": schema.StringAttribute{
+				MarkdownDescription: "name of the app to deploy to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_ref": schema.StringAttribute{
+				MarkdownDescription: "id of a source uploaded to the app (`sourceID` of the sources upload response), which is built and deployed",
+				Required:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "port the app listens on, which Liara proxies to, a new deployment is made when it changes (default: the platform default)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"deployment_id": schema.StringAttribute{
+				MarkdownDescription: "id of the latest deployment",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "state of the latest deployment, e.g. `READY`",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, appDeploymentTimeoutsOpts),
+		},
+	}
+}
+
+func (r *AppDeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.PaasClient
+	r.timeout = providerData.Timeout
+	r.clock = providerData.Clock
+}
+
+func (r *AppDeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var configuredTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &configuredTimeouts)...)
+	createTimeout, diags := configuredTimeouts.Create(ctx, r.timeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withOperationTimeout(ctx, createTimeout, &resp.Diagnostics)
+	defer done()
+
+	var data AppDeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.deploy(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created an app deployment resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppDeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data AppDeploymentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, found := r.findRelease(ctx, data.AppName.ValueString(), data.DeploymentID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		// the app was removed outside of terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// releases beyond the first page aren't listed, their last known state
+	// is kept.
+	if release != nil {
+		data.Status = types.StringValue(release.State)
+	}
+
+	tflog.Trace(ctx, "read app deployment resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppDeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var configuredTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &configuredTimeouts)...)
+	updateTimeout, diags := configuredTimeouts.Update(ctx, r.timeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withOperationTimeout(ctx, updateTimeout, &resp.Diagnostics)
+	defer done()
+
+	var data, state AppDeploymentResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SourceRef.Equal(state.SourceRef) && data.Port.Equal(state.Port) {
+		data.DeploymentID = state.DeploymentID
+		data.Status = state.Status
+	} else {
+		r.deploy(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppDeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// the API has no way to remove a release, and the app keeps running the
+	// latest one, so the deployment is only removed from the state.
+	tflog.Trace(ctx, "deleted app deployment resource")
+}
+
+// deploy starts a deployment of the source, and waits for it to be deployed.
+// A failed deployment is reported with the tail of the app logs.
+func (r *AppDeploymentResource) deploy(ctx context.Context, data *AppDeploymentResourceModel, diagnostics *diag.Diagnostics) {
+	name := data.AppName.ValueString()
+
+	app := getApp(ctx, r.client, name, diagnostics)
+	if app == nil {
+		return
+	}
+
+	release := paas.ReleasesDeployJSONRequestBody{
+		Type:     &app.Project.Type,
+		SourceID: data.SourceRef.ValueStringPointer(),
+	}
+
+	// the platform default port is used when it isn't set.
+	if !data.Port.IsNull() {
+		port := float32(data.Port.ValueInt64())
+		release.Port = &port
+	}
+
+	response, err := r.client.ReleasesDeploy(ctx, name, release)
+	if err != nil {
+		diagnostics.AddError("Deploying app failed", fmt.Sprintf("Unable to deploy app, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading deploy response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Deploying app failed", fmt.Sprintf("Unable to deploy app, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	responseModel := struct {
+		ReleaseID string `json:"releaseID"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		diagnostics.AddError("Decoding deploy response failed", fmt.Sprintf("Unable to decode deploy response, got error: %s", err))
+		return
+	}
+
+	data.DeploymentID = types.StringValue(responseModel.ReleaseID)
+
+	tflog.Trace(ctx, "started a deployment", map[string]any{"deployment_id": responseModel.ReleaseID})
+
+	deployed := r.waitForRelease(ctx, name, responseModel.ReleaseID, diagnostics)
+	if deployed == nil {
+		return
+	}
+
+	data.Status = types.StringValue(deployed.State)
+
+	if slices.Contains(appReleaseFailedStates, deployed.State) {
+		diagnostics.AddError(
+			"Deployment failed",
+			fmt.Sprintf("Deployment %s of app %s finished with state %s.%s", deployed.ID, name, deployed.State, r.logTail(ctx, name, deployed)),
+		)
+	}
+}
+
+// waitForRelease polls the releases of the app until the release is deployed
+// or fails, it returns nil if the release couldn't be read.
+func (r *AppDeploymentResource) waitForRelease(ctx context.Context, name string, id string, diagnostics *diag.Diagnostics) *appReleaseModel {
+	for {
+		release, found := r.findRelease(ctx, name, id, diagnostics)
+		if diagnostics.HasError() {
+			return nil
+		}
+
+		if !found {
+			diagnostics.AddError("App not found", fmt.Sprintf("App %q does not exist", name))
+			return nil
+		}
+
+		// a new release may not be listed yet.
+		if release != nil && (release.State == appReleaseSucceededState || slices.Contains(appReleaseFailedStates, release.State)) {
+			return release
+		}
+
+		tflog.Debug(ctx, "waiting for the deployment", map[string]any{"deployment_id": id})
+
+		if err := sleepContext(ctx, r.clock, appDeploymentPollInterval); err != nil {
+			if errors.Is(err, context.Canceled) {
+				addOperationCancelledError(diagnostics)
+				return nil
+			}
+
+			diagnostics.AddError("Waiting for deployment failed", fmt.Sprintf("Deployment %s of app %s didn't finish in time, got error: %s", id, name, err))
+			return nil
+		}
+	}
+}
+
+// findRelease looks the release up in the latest releases of the app. It
+// returns false if the app doesn't exist, and a nil release if the release
+// isn't among the latest ones.
+func (r *AppDeploymentResource) findRelease(ctx context.Context, name string, id string, diagnostics *diag.Diagnostics) (*appReleaseModel, bool) {
+	response, err := r.client.GetAppReleases(ctx, name, &paas.GetAppReleasesParams{
+		Page:  1,
+		Count: 10,
+	})
+	if err != nil {
+		diagnostics.AddError("Reading app deployments failed", fmt.Sprintf("Unable to read app deployments, got error: %s", err))
+		return nil, false
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, false
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading response payload failed", err.Error())
+
+			return nil, false
+		}
+
+		diagnostics.AddError("Reading app deployments failed", fmt.Sprintf("Unable to read app deployments, got error: %s", responseErrorMessage(response, body)))
+		return nil, false
+	}
+
+	responseModel := struct {
+		Releases []appReleaseModel `json:"releases"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		diagnostics.AddError("Decoding read response failed", fmt.Sprintf("Unable to decode read response, got error: %s", err))
+		return nil, false
+	}
+
+	for _, release := range responseModel.Releases {
+		if release.ID == id {
+			return &release, true
+		}
+	}
+
+	return nil, true
+}
+
+// logTail returns the last lines the app logged since the release was
+// created, formatted to be appended to a diagnostic. Logs are best effort, so
+// it returns an empty string if they can't be read.
+func (r *AppDeploymentResource) logTail(ctx context.Context, name string, release *appReleaseModel) string {
+	since := "0"
+	if createdAt, err := time.Parse(time.RFC3339, release.CreatedAt); err == nil {
+		since = strconv.FormatInt(createdAt.Unix(), 10)
+	}
+
+	response, err := r.client.GetAppLogs(ctx, name, &paas.GetAppLogsParams{Since: since})
+	if err != nil {
+		tflog.Debug(ctx, "unable to read the deployment logs", map[string]any{"error": err.Error()})
+		return ""
+	}
+	defer closeResponseBody(response.Body)
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil || response.StatusCode != http.StatusOK {
+		tflog.Debug(ctx, "unable to read the deployment logs", map[string]any{"status": response.StatusCode})
+		return ""
+	}
+
+	lines := logMessageLines(string(body))
+	if len(lines) == 0 {
+		return ""
+	}
+
+	if len(lines) > appDeploymentLogTailLines {
+		lines = lines[len(lines)-appDeploymentLogTailLines:]
+	}
+
+	return "\n\nThe last log lines of the app:\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+func TestAppDeploymentResource(t *testing.T) {
+	testCases := []struct {
+		name        string
+		finalState  string
+		expectError bool
+	}{
+		{name: "successful deployment", finalState: "READY"},
+		{name: "failed deployment", finalState: "FAILED", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var deployBodies []map[string]any
+			releasePolls := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/projects/my-app":
+					_, _ = w.Write([]byte(`{"project":{"_id":"id","project_id":"my-app","type":"docker"}}`))
+				case r.Method == http.MethodPost && r.URL.Path == "/v2/projects/my-app/releases":
+					var body map[string]any
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					deployBodies = append(deployBodies, body)
+
+					_, _ = fmt.Fprintf(w, `{"releaseID":"r%d"}`, len(deployBodies))
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/projects/my-app/releases":
+					releasePolls++
+
+					// the new release is building on the first poll.
+					state := tc.finalState
+					if releasePolls == 1 {
+						state = "BUILDING"
+					}
+
+					_, _ = fmt.Fprintf(w, `{"releases":[{"_id":"r%d","state":%q,"createdAt":"2024-01-01T10:00:00Z"}]}`, len(deployBodies), state)
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/projects/my-app/logs":
+					if since := r.URL.Query().Get("since"); since != "1704103200" {
+						t.Errorf("expected logs since the release creation, got since %q", since)
+					}
+
+					_, _ = w.Write([]byte(`[{"message":"installing dependencies"},{"message":"npm ERR! missing script: build\n"}]`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := paas.NewClient(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			r := &AppDeploymentResource{client: client, clock: &fakeClock{}}
+
+			schemaResp := fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+			objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			plan := tfsdk.Plan{
+				Schema: schemaResp.Schema,
+				Raw: testObjectValue(objectType, map[string]tftypes.Value{
+					"app_name":      tftypes.NewValue(tftypes.String, "my-app"),
+					"source_ref":    tftypes.NewValue(tftypes.String, "s1"),
+					"port":          tftypes.NewValue(tftypes.Number, 8080),
+					"deployment_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+					"status":        tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				}),
+			}
+
+			resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+
+			if len(deployBodies) != 1 {
+				t.Fatalf("expected one deployment, got %d", len(deployBodies))
+			}
+
+			if deployBodies[0]["sourceID"] != "s1" || deployBodies[0]["type"] != "docker" || deployBodies[0]["port"] != float64(8080) {
+				t.Errorf("unexpected deploy request %v", deployBodies[0])
+			}
+
+			if releasePolls != 2 {
+				t.Errorf("expected the release to be polled until it finished, got %d polls", releasePolls)
+			}
+
+			if !tc.expectError {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+
+				var data AppDeploymentResourceModel
+				resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+				if data.DeploymentID.ValueString() != "r1" || data.Status.ValueString() != "READY" {
+					t.Errorf("expected deployment r1 to be READY, got %s %s", data.DeploymentID, data.Status)
+				}
+
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected the failed deployment to be reported")
+			}
+
+			detail := resp.Diagnostics.Errors()[0].Detail()
+			if !strings.Contains(detail, "FAILED") || !strings.Contains(detail, "npm ERR! missing script: build") {
+				t.Errorf("expected the state and the log tail in the diagnostic, got: %s", detail)
+			}
+		})
+	}
+}
+
+func TestAppDeploymentResourcePortValidation(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := fwresource.SchemaResponse{}
+	(&AppDeploymentResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	attribute, ok := schemaResp.Schema.Attributes["port"].(schema.Int64Attribute)
+	if !ok {
+		t.Fatalf("unexpected port attribute type %T", schemaResp.Schema.Attributes["port"])
+	}
+
+	for port, expectError := range map[int64]bool{0: true, 1: false, 8080: false, 65535: false, 65536: true} {
+		req := validator.Int64Request{
+			Path:        path.Root("port"),
+			ConfigValue: types.Int64Value(port),
+		}
+		resp := validator.Int64Response{}
+
+		for _, v := range attribute.Validators {
+			v.ValidateInt64(ctx, req, &resp)
+		}
+
+		if resp.Diagnostics.HasError() != expectError {
+			t.Errorf("port %d: expected error %t, got: %v", port, expectError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestAppDeploymentResourceUpdateTimeout(t *testing.T) {
+	var deployBodies []map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/projects/my-app":
+			_, _ = w.Write([]byte(`{"project":{"_id":"id","project_id":"my-app","type":"node"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/projects/my-app/releases":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			deployBodies = append(deployBodies, body)
+
+			_, _ = w.Write([]byte(`{"releaseID":"r2"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/projects/my-app/releases":
+			// the new release never finishes building.
+			_, _ = w.Write([]byte(`{"releases":[{"_id":"r2","state":"BUILDING"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	// the provider timeout is longer than the test, so only the update
+	// timeout can end the wait for the release.
+	r := &AppDeploymentResource{client: client, timeout: time.Hour}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	timeoutsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"create": tftypes.String,
+		"update": tftypes.String,
+	}}
+	attributes := map[string]tftypes.Value{
+		"app_name":      tftypes.NewValue(tftypes.String, "my-app"),
+		"source_ref":    tftypes.NewValue(tftypes.String, "s1"),
+		"port":          tftypes.NewValue(tftypes.Number, 3000),
+		"deployment_id": tftypes.NewValue(tftypes.String, "r1"),
+		"status":        tftypes.NewValue(tftypes.String, "READY"),
+		"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+			"create": tftypes.NewValue(tftypes.String, nil),
+			"update": tftypes.NewValue(tftypes.String, "50ms"),
+		}),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: testObjectValue(objectType, attributes)}
+
+	attributes["port"] = tftypes.NewValue(tftypes.Number, 8080)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: testObjectValue(objectType, attributes)}
+
+	start := time.Now()
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+
+	if len(deployBodies) != 1 || deployBodies[0]["port"] != float64(8080) {
+		t.Fatalf("expected the changed port to be deployed once, got %v", deployBodies)
+	}
+
+	if elapsed := time.Since(start); elapsed >= appDeploymentPollInterval {
+		t.Errorf("expected the update to time out after 50ms, took %s", elapsed)
+	}
+
+	var timedOut bool
+	for _, d := range resp.Diagnostics.Errors() {
+		if d.Summary() == "Operation timed out" && strings.Contains(d.Detail(), "50ms") {
+			timedOut = true
+		}
+	}
+
+	if !timedOut {
+		t.Errorf("expected the update to time out, got: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+const defaultDeploymentsLimit int64 = 10
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AppDeploymentsDataSource{}
+
+func NewAppDeploymentsDataSource() datasource.DataSource {
+	return &AppDeploymentsDataSource{}
+}
+
+// AppDeploymentsDataSource defines the data source implementation.
+type AppDeploymentsDataSource struct {
+	client paas.ClientInterface
+}
+
+// AppDeploymentsDataSourceModel describes the data source data model.
+type AppDeploymentsDataSourceModel struct {
+	Name        types.String         `tfsdk:"name"`
+	Limit       types.Int64          `tfsdk:"limit"`
+	Deployments []AppDeploymentModel `tfsdk:"deployments"`
+}
+
+// AppDeploymentModel describes a single deployment of an app.
+type AppDeploymentModel struct {
+	ID        types.String `tfsdk:"id"`
+	Image     types.String `tfsdk:"image"`
+	Status    types.String `tfsdk:"status"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	Duration  types.Int64  `tfsdk:"duration"`
+}
+
+func (d *AppDeploymentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_deployments"
+}
+
+func (d *AppDeploymentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "App deployments data source, lists the recent deployments of an app (newest first)",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "app name",
+				Required:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("maximum number of deployments to return (default: %d)", defaultDeploymentsLimit),
+				Optional:            true,
+			},
+			"deployments": schema.ListNestedAttribute{
+				MarkdownDescription: "deployments, newest first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "deployment (release) id",
+							Computed:            true,
+						},
+						"image": schema.StringAttribute{
+							MarkdownDescription: "deployed image",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "deployment status",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "deployment creation time",
+							Computed:            true,
+						},
+						"duration": schema.Int64Attribute{
+							MarkdownDescription: "deployment duration in seconds (null while the deployment is in progress)",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AppDeploymentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.PaasClient
+}
+
+func (d *AppDeploymentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppDeploymentsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultDeploymentsLimit
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	if limit < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("limit"),
+			"Invalid limit",
+			fmt.Sprintf("limit must be a positive number, got: %d", limit),
+		)
+
+		return
+	}
+
+	response, err := d.client.GetAppReleases(ctx, data.Name.ValueString(), &paas.GetAppReleasesParams{
+		Page:  1,
+		Count: float32(limit),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Reading app deployments failed", fmt.Sprintf("Unable to read app deployments, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			resp.Diagnostics.AddError("reading response payload failed", err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Reading app deployments failed", fmt.Sprintf("Unable to read app deployments, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	responseModel := struct {
+		Releases []struct {
+			ID         string `json:"_id"`
+			ImageName  string `json:"imageName"`
+			State      string `json:"state"`
+			CreatedAt  string `json:"createdAt"`
+			FinishedAt string `json:"finishedAt"`
+		} `json:"releases"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		resp.Diagnostics.AddError("Decoding read response failed", fmt.Sprintf("Unable to decode read response, got error: %s", err))
+		return
+	}
+
+	releases := responseModel.Releases
+
+	// releases with an unparsable creation time are sorted last.
+	sort.SliceStable(releases, func(i, j int) bool {
+		createdAtI, _ := time.Parse(time.RFC3339, releases[i].CreatedAt)
+		createdAtJ, _ := time.Parse(time.RFC3339, releases[j].CreatedAt)
+
+		return createdAtI.After(createdAtJ)
+	})
+
+	if int64(len(releases)) > limit {
+		releases = releases[:limit]
+	}
+
+	data.Deployments = make([]AppDeploymentModel, 0, len(releases))
+	for _, release := range releases {
+		deployment := AppDeploymentModel{
+			ID:        types.StringValue(release.ID),
+			Image:     types.StringValue(release.ImageName),
+			Status:    types.StringValue(release.State),
+			CreatedAt: types.StringValue(release.CreatedAt),
+			Duration:  types.Int64Null(),
+		}
+
+		createdAt, createdErr := time.Parse(time.RFC3339, release.CreatedAt)
+		finishedAt, finishedErr := time.Parse(time.RFC3339, release.FinishedAt)
+		if createdErr == nil && finishedErr == nil {
+			deployment.Duration = types.Int64Value(int64(finishedAt.Sub(createdAt).Seconds()))
+		}
+
+		data.Deployments = append(data.Deployments, deployment)
+	}
+
+	tflog.Trace(ctx, "read app deployments data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+func TestAppDeploymentsDataSourceRead(t *testing.T) {
+	var requestedCount string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/my-app/releases" {
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+		}
+
+		requestedCount = r.URL.Query().Get("count")
+
+		_, _ = w.Write([]byte(`{"releases":[
+			{"_id":"r1","imageName":"my-app:v1","state":"READY","createdAt":"2024-01-01T10:00:00Z","finishedAt":"2024-01-01T10:01:30Z"},
+			{"_id":"r3","imageName":"my-app:v3","state":"BUILDING","createdAt":"2024-01-03T10:00:00Z"},
+			{"_id":"r2","imageName":"my-app:v2","state":"FAILED","createdAt":"2024-01-02T10:00:00Z","finishedAt":"2024-01-02T10:00:10Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	d := &AppDeploymentsDataSource{client: client}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "my-app"),
+		"limit": tftypes.NewValue(tftypes.Number, 2),
+	})
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+	}
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	d.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if requestedCount != "2" {
+		t.Errorf("expected the limit to be sent as count, got %q", requestedCount)
+	}
+
+	var data AppDeploymentsDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(data.Deployments) != 2 {
+		t.Fatalf("expected 2 deployments, got %d", len(data.Deployments))
+	}
+
+	if id := data.Deployments[0].ID.ValueString(); id != "r3" {
+		t.Errorf("expected newest deployment r3 first, got %s", id)
+	}
+
+	if !data.Deployments[0].Duration.IsNull() {
+		t.Errorf("expected in-progress deployment to have a null duration, got %s", data.Deployments[0].Duration)
+	}
+
+	if id := data.Deployments[1].ID.ValueString(); id != "r2" {
+		t.Errorf("expected deployment r2 second, got %s", id)
+	}
+
+	if duration := data.Deployments[1].Duration.ValueInt64(); duration != 10 {
+		t.Errorf("expected a duration of 10 seconds, got %d", duration)
+	}
+
+	if status := data.Deployments[1].Status.ValueString(); status != "FAILED" {
+		t.Errorf("expected status FAILED, got %s", status)
+	}
+}
@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AppDiskUsageDataSource{}
+
+func NewAppDiskUsageDataSource() datasource.DataSource {
+	return &AppDiskUsageDataSource{}
+}
+
+// AppDiskUsageDataSource defines the data source implementation.
+type AppDiskUsageDataSource struct {
+	client paas.ClientInterface
+}
+
+// AppDiskUsageDataSourceModel describes the data source data model.
+type AppDiskUsageDataSourceModel struct {
+	Name              types.String        `tfsdk:"name"`
+	ReservedDiskSpace types.Float64       `tfsdk:"reserved_disk_space"`
+	UsedDiskSpace     types.Float64       `tfsdk:"used_disk_space"`
+	Disks             []AppDiskUsageModel `tfsdk:"disks"`
+}
+
+// AppDiskUsageModel describes the usage of a single disk of an app.
+type AppDiskUsageModel struct {
+	Name       types.String  `tfsdk:"name"`
+	Size       types.Float64 `tfsdk:"size"`
+	Usage      types.Float64 `tfsdk:"usage"`
+	ReportedAt types.String  `tfsdk:"reported_at"`
+}
+
+func (d *AppDiskUsageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_disk_usage"
+}
+
+func (d *AppDiskUsageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "App disk usage data source, reads the disk space reserved and used by the disks of an app. Apps without disks report zero",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "app name",
+				Required:            true,
+			},
+			"reserved_disk_space": schema.Float64Attribute{
+				MarkdownDescription: "disk space reserved by the disks of the app",
+				Computed:            true,
+			},
+			"used_disk_space": schema.Float64Attribute{
+				MarkdownDescription: "disk space used by the disks of the app, as of the last report",
+				Computed:            true,
+			},
+			"disks": schema.ListNestedAttribute{
+				MarkdownDescription: "usage of each disk of the app",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "disk name",
+							Computed:            true,
+						},
+						"size": schema.Float64Attribute{
+							MarkdownDescription: "disk size",
+							Computed:            true,
+						},
+						"usage": schema.Float64Attribute{
+							MarkdownDescription: "disk space used on the disk",
+							Computed:            true,
+						},
+						"reported_at": schema.StringAttribute{
+							MarkdownDescription: "time the usage was reported",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AppDiskUsageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.PaasClient
+}
+
+func (d *AppDiskUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppDiskUsageDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reservedDiskSpace := d.readReservedDiskSpace(ctx, data.Name.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	disks := d.readDisksUsage(ctx, data.Name.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usedDiskSpace := 0.0
+	for _, disk := range disks {
+		usedDiskSpace += disk.Usage.ValueFloat64()
+	}
+
+	data.ReservedDiskSpace = types.Float64Value(reservedDiskSpace)
+	data.UsedDiskSpace = types.Float64Value(usedDiskSpace)
+	data.Disks = disks
+
+	tflog.Trace(ctx, "read app disk usage data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readReservedDiskSpace returns the disk space reserved by the disks of the
+// app.
+func (d *AppDiskUsageDataSource) readReservedDiskSpace(ctx context.Context, name string, diagnostics *diag.Diagnostics) float64 {
+	app := getApp(ctx, d.client, name, diagnostics)
+	if app == nil {
+		return 0
+	}
+
+	return app.Project.ReservedDiskSpace
+}
+
+// readDisksUsage returns the last reported usage of the disks of the app.
+func (d *AppDiskUsageDataSource) readDisksUsage(ctx context.Context, name string, diagnostics *diag.Diagnostics) []AppDiskUsageModel {
+	response, err := d.client.GetAppSummaryReports(ctx, name)
+	if err != nil {
+		diagnostics.AddError("Reading disk usage failed", fmt.Sprintf("Unable to read disk usage, got error: %s", err))
+		return nil
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading response payload failed", err.Error())
+
+			return nil
+		}
+
+		diagnostics.AddError("Reading disk usage failed", fmt.Sprintf("Unable to read disk usage, got error: %s", responseErrorMessage(response, body)))
+		return nil
+	}
+
+	responseModel := struct {
+		DisksUsage []struct {
+			Name       string `json:"name"`
+			Size       string `json:"size"`
+			Usage      string `json:"usage"`
+			ReportedAt string `json:"reportedAt"`
+		} `json:"disksUsage"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		diagnostics.AddError("Decoding disk usage response failed", fmt.Sprintf("Unable to decode disk usage response, got error: %s", err))
+		return nil
+	}
+
+	disks := make([]AppDiskUsageModel, 0, len(responseModel.DisksUsage))
+	for _, disk := range responseModel.DisksUsage {
+		size, err := parseDiskSpace(disk.Size)
+		if err != nil {
+			diagnostics.AddError("Decoding disk usage response failed", fmt.Sprintf("Unable to parse the size of disk %q, got error: %s", disk.Name, err))
+			return nil
+		}
+
+		usage, err := parseDiskSpace(disk.Usage)
+		if err != nil {
+			diagnostics.AddError("Decoding disk usage response failed", fmt.Sprintf("Unable to parse the usage of disk %q, got error: %s", disk.Name, err))
+			return nil
+		}
+
+		disks = append(disks, AppDiskUsageModel{
+			Name:       types.StringValue(disk.Name),
+			Size:       types.Float64Value(size),
+			Usage:      types.Float64Value(usage),
+			ReportedAt: types.StringValue(disk.ReportedAt),
+		})
+	}
+
+	return disks
+}
+
+// parseDiskSpace parses a disk space reported as a string, an empty value
+// is reported for disks which weren't measured yet.
+func parseDiskSpace(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+func TestAppDiskUsageDataSourceRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/projects/with-disk":
+			_, _ = w.Write([]byte(`{"project":{"project_id":"with-disk","reservedDiskSpace":5}}`))
+		case "/v1/projects/with-disk/metrics/summary":
+			_, _ = w.Write([]byte(`{"disksUsage":[
+				{"name":"data","size":"4","usage":"1.5","reportedAt":"2024-01-02T03:04:05.000Z"},
+				{"name":"uploads","size":"1","usage":"0.25","reportedAt":"2024-01-02T03:04:05.000Z"}
+			]}`))
+		case "/v1/projects/without-disk":
+			_, _ = w.Write([]byte(`{"project":{"project_id":"without-disk"}}`))
+		case "/v1/projects/without-disk/metrics/summary":
+			_, _ = w.Write([]byte(`{"cpuUsage":[]}`))
+		default:
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	d := &AppDiskUsageDataSource{client: client}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	testCases := []struct {
+		name           string
+		app            string
+		expectReserved float64
+		expectUsed     float64
+		expectDisks    int
+	}{
+		{name: "with disk", app: "with-disk", expectReserved: 5, expectUsed: 1.75, expectDisks: 2},
+		{name: "without disk", app: "without-disk", expectReserved: 0, expectUsed: 0, expectDisks: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw: testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+						"name": tftypes.NewValue(tftypes.String, tc.app),
+					}),
+				},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+
+			d.Read(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data AppDiskUsageDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if data.ReservedDiskSpace.ValueFloat64() != tc.expectReserved {
+				t.Errorf("expected reserved disk space %v, got %s", tc.expectReserved, data.ReservedDiskSpace)
+			}
+
+			if data.UsedDiskSpace.ValueFloat64() != tc.expectUsed {
+				t.Errorf("expected used disk space %v, got %s", tc.expectUsed, data.UsedDiskSpace)
+			}
+
+			if len(data.Disks) != tc.expectDisks {
+				t.Errorf("expected %d disks, got %d", tc.expectDisks, len(data.Disks))
+			}
+		})
+	}
+}
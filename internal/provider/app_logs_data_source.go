@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	defaultLogsMaxLines int64 = 100
+	defaultLogsDuration int64 = 5
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AppLogsDataSource{}
+
+func NewAppLogsDataSource() datasource.DataSource {
+	return &AppLogsDataSource{}
+}
+
+// AppLogsDataSource defines the data source implementation.
+type AppLogsDataSource struct {
+	websocketEndpoint string
+	origin            string
+	accessToken       string
+	userAgent         string
+	defaultHeaders    map[string]string
+	transport         *http.Transport
+}
+
+// AppLogsDataSourceModel describes the data source data model.
+type AppLogsDataSourceModel struct {
+	Name     types.String `tfsdk:"name"`
+	Since    types.Int64  `tfsdk:"since"`
+	MaxLines types.Int64  `tfsdk:"max_lines"`
+	Duration types.Int64  `tfsdk:"duration"`
+	Lines    types.List   `tfsdk:"lines"`
+}
+
+// appLogEntry is a single log entry sent by the logs websocket.
+type appLogEntry struct {
+	Datetime string `json:"datetime"`
+	Message  string `json:"message"`
+	Type     string `json:"type"`
+}
+
+func (d *AppLogsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_logs"
+}
+
+func (d *AppLogsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "App logs data source, collects the recent log lines of an app from the websocket endpoint",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "app name",
+				Required:            true,
+			},
+			"since": schema.Int64Attribute{
+				MarkdownDescription: "only collect logs since the given unix timestamp",
+				Optional:            true,
+			},
+			"max_lines": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("maximum number of lines to collect (default: %d)", defaultLogsMaxLines),
+				Optional:            true,
+			},
+			"duration": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("maximum time in seconds to collect logs for (default: %d)", defaultLogsDuration),
+				Optional:            true,
+			},
+			"lines": schema.ListAttribute{
+				MarkdownDescription: "collected log lines, oldest first",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AppLogsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	// the logs can't be read without the websocket endpoint, so it is
+	// checked before any dial is attempted.
+	if len(providerData.WebsocketEndpoint) == 0 {
+		resp.Diagnostics.AddError(
+			"Missing Liara Websocket Endpoint",
+			"The liara_app_logs data source requires the Liara websocket endpoint. "+
+				"Set the websocket_endpoint or region value in the provider configuration or use the LIARA_WEBSOCKET_ENDPOINT environment variable.",
+		)
+
+		return
+	}
+
+	d.websocketEndpoint = providerData.WebsocketEndpoint
+	d.origin = providerData.APIEndpoint
+	d.accessToken = providerData.AccessToken
+	d.userAgent = providerData.UserAgent
+	d.defaultHeaders = providerData.DefaultHeaders
+	d.transport = providerData.Transport
+}
+
+func (d *AppLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppLogsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxLines := defaultLogsMaxLines
+	if !data.MaxLines.IsNull() {
+		maxLines = data.MaxLines.ValueInt64()
+	}
+
+	if maxLines < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_lines"),
+			"Invalid max_lines",
+			fmt.Sprintf("max_lines must be a positive number, got: %d", maxLines),
+		)
+	}
+
+	duration := defaultLogsDuration
+	if !data.Duration.IsNull() {
+		duration = data.Duration.ValueInt64()
+	}
+
+	if duration < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("duration"),
+			"Invalid duration",
+			fmt.Sprintf("duration must be a positive number, got: %d", duration),
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the duration running out ends the read, while the operation being
+	// cancelled fails it.
+	operationCtx := ctx
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(duration)*time.Second)
+	defer cancel()
+
+	location := fmt.Sprintf("%s/v1/projects/%s/logs", strings.TrimRight(d.websocketEndpoint, "/"), url.PathEscape(data.Name.ValueString()))
+	if !data.Since.IsNull() {
+		location += "?since=" + strconv.FormatInt(data.Since.ValueInt64(), 10)
+	}
+
+	config, err := websocket.NewConfig(location, d.origin)
+	if err != nil {
+		resp.Diagnostics.AddError("Reading app logs failed", fmt.Sprintf("Unable to configure the websocket connection, got error: %s", err))
+		return
+	}
+	config.Header = http.Header{}
+	for name, value := range d.defaultHeaders {
+		config.Header.Set(name, value)
+	}
+	config.Header.Set("Authorization", fmt.Sprintf("Bearer %s", d.accessToken))
+	if len(d.userAgent) > 0 {
+		config.Header.Set("User-Agent", d.userAgent)
+	}
+
+	conn, err := dialWebsocket(ctx, config, d.transport)
+	if err != nil {
+		resp.Diagnostics.AddError("Reading app logs failed", fmt.Sprintf("Unable to connect to the logs websocket, got error: %s", err))
+		return
+	}
+	defer conn.Close()
+
+	// closing the connection unblocks the pending receive once the duration
+	// is over or the operation is cancelled.
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	lines := make([]string, 0, maxLines)
+	for int64(len(lines)) < maxLines {
+		var message string
+		if err := websocket.Message.Receive(conn, &message); err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				break
+			}
+
+			resp.Diagnostics.AddError("Reading app logs failed", fmt.Sprintf("Unable to receive app logs, got error: %s", err))
+			return
+		}
+
+		lines = append(lines, logMessageLines(message)...)
+	}
+
+	if errors.Is(operationCtx.Err(), context.Canceled) {
+		addOperationCancelledError(&resp.Diagnostics)
+		return
+	}
+
+	if int64(len(lines)) > maxLines {
+		lines = lines[:maxLines]
+	}
+
+	linesValue, diags := types.ListValueFrom(ctx, types.StringType, lines)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Lines = linesValue
+
+	tflog.Trace(ctx, "read app logs data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// logMessageLines returns the log lines of a websocket message, which is
+// either a log entry, a list of log entries or plain text.
+func logMessageLines(message string) []string {
+	var entries []appLogEntry
+	if err := json.Unmarshal([]byte(message), &entries); err != nil {
+		var entry appLogEntry
+		if err := json.Unmarshal([]byte(message), &entry); err == nil {
+			entries = []appLogEntry{entry}
+		}
+	}
+
+	var lines []string
+	if entries != nil {
+		for _, entry := range entries {
+			lines = append(lines, splitLogLines(entry.Message)...)
+		}
+
+		return lines
+	}
+
+	return splitLogLines(message)
+}
+
+func splitLogLines(text string) []string {
+	text = strings.TrimRight(text, "\n")
+	if len(text) == 0 {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
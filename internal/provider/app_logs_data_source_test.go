@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/net/websocket"
+)
+
+func TestAppLogsDataSourceRead(t *testing.T) {
+	testCases := []struct {
+		name        string
+		maxLines    int64
+		expectLines []string
+	}{
+		{
+			name:        "until the server closes the connection",
+			maxLines:    10,
+			expectLines: []string{"first", "second", "third", "fourth"},
+		},
+		{
+			name:        "until max lines",
+			maxLines:    2,
+			expectLines: []string{"first", "second"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requestedPath, requestedSince, authorization string
+
+			server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+				requestedPath = conn.Request().URL.Path
+				requestedSince = conn.Request().URL.Query().Get("since")
+				authorization = conn.Request().Header.Get("Authorization")
+
+				for _, message := range []string{
+					`{"datetime":"2024-01-01T10:00:00Z","message":"first","type":"stdout"}`,
+					`[{"message":"second\n"},{"message":"third"}]`,
+					"fourth\n",
+				} {
+					if err := websocket.Message.Send(conn, message); err != nil {
+						return
+					}
+				}
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			d := &AppLogsDataSource{
+				websocketEndpoint: "ws" + strings.TrimPrefix(server.URL, "http"),
+				origin:            server.URL,
+				accessToken:       "token",
+			}
+
+			schemaResp := datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			config := testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"name":      tftypes.NewValue(tftypes.String, "my-app"),
+				"since":     tftypes.NewValue(tftypes.Number, 1700000000),
+				"max_lines": tftypes.NewValue(tftypes.Number, tc.maxLines),
+			})
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+
+			d.Read(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if requestedPath != "/v1/projects/my-app/logs" {
+				t.Errorf("unexpected request path: %s", requestedPath)
+			}
+
+			if requestedSince != "1700000000" {
+				t.Errorf("expected since to be sent, got %q", requestedSince)
+			}
+
+			if authorization != "Bearer token" {
+				t.Errorf("expected the access token to be sent, got %q", authorization)
+			}
+
+			var data AppLogsDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var lines []string
+			resp.Diagnostics.Append(data.Lines.ElementsAs(ctx, &lines, false)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if strings.Join(lines, ",") != strings.Join(tc.expectLines, ",") {
+				t.Errorf("expected lines %v, got %v", tc.expectLines, lines)
+			}
+		})
+	}
+}
+
+func TestAppLogsDataSourceConfigureMissingWebsocketEndpoint(t *testing.T) {
+	providerData, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token":       tftypes.NewValue(tftypes.String, "token"),
+		"websocket_endpoint": tftypes.NewValue(tftypes.String, ""),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	d := &AppLogsDataSource{}
+
+	resp := datasource.ConfigureResponse{}
+	d.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: providerData}, &resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Missing Liara Websocket Endpoint" {
+		t.Fatalf("expected a missing websocket endpoint error, got: %v", resp.Diagnostics)
+	}
+
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "websocket_endpoint") {
+		t.Errorf("expected the diagnostic to name the websocket_endpoint setting, got: %s", detail)
+	}
+}
@@ -1,27 +1,48 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+	"golang.org/x/sync/errgroup"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AppResource{}
 var _ resource.ResourceWithImportState = &AppResource{}
+var _ resource.ResourceWithValidateConfig = &AppResource{}
+var _ resource.ResourceWithUpgradeState = &AppResource{}
+var _ resource.ResourceWithModifyPlan = &AppResource{}
 
 func NewAppResource() resource.Resource {
 	return &AppResource{}
@@ -29,7 +50,9 @@ func NewAppResource() resource.Resource {
 
 // AppResource defines the resource implementation.
 type AppResource struct {
-	client paas.ClientInterface
+	client  paas.ClientInterface
+	timeout time.Duration
+	clock   clock
 }
 
 // AppResourceModel describes the resource data model.
@@ -42,12 +65,140 @@ type AppResourceModel struct {
 	ReadOnlyRootFilesystem types.Bool   `tfsdk:"read_only_root_filesystem"`
 	NetworkName            types.String `tfsdk:"network_name"`
 
-	RollingUpdate           types.Bool   `tfsdk:"rolling_update"`
+	ZeroDowntime            types.Bool   `tfsdk:"zero_downtime"`
+	RestartTrigger          types.String `tfsdk:"restart_trigger"`
 	TurnOff                 types.Bool   `tfsdk:"turn_off"`
+	Scale                   types.Int64  `tfsdk:"scale"`
 	Envs                    types.Map    `tfsdk:"envs"`
+	SecretEnvs              types.Map    `tfsdk:"secret_envs"`
+	SecretEnvsWO            types.Map    `tfsdk:"secret_envs_wo"`
+	SecretEnvsWOVersion     types.Int64  `tfsdk:"secret_envs_wo_version"`
+	SecretEnvsWOKeys        types.Set    `tfsdk:"secret_envs_wo_keys"`
 	StaticIP                types.String `tfsdk:"static_ip"`
 	EnableStaticIP          types.Bool   `tfsdk:"enable_static_ip"`
 	DisableDefaultSubDomain types.Bool   `tfsdk:"disable_default_subdomain"`
+	Disks                   types.List   `tfsdk:"disks"`
+	Domains                 types.Set    `tfsdk:"domains"`
+	DomainVerifications     types.Map    `tfsdk:"domain_verifications"`
+
+	WaitForReady       types.Bool `tfsdk:"wait_for_ready"`
+	AdoptExisting      types.Bool `tfsdk:"adopt_existing"`
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
+	HourlyPrice types.Float64 `tfsdk:"hourly_price"`
+	IsDeployed  types.Bool    `tfsdk:"is_deployed"`
+	Status      types.String  `tfsdk:"status"`
+	CreatedAt   types.String  `tfsdk:"created_at"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// AppDiskModel describes a disk attached to the app.
+type AppDiskModel struct {
+	Name      types.String `tfsdk:"name"`
+	MountPath types.String `tfsdk:"mount_path"`
+	SizeGB    types.Int64  `tfsdk:"size_gb"`
+}
+
+// AppDomainVerificationModel describes the verification state of a custom
+// domain and the DNS records needed to verify it.
+type AppDomainVerificationModel struct {
+	Status     types.String        `tfsdk:"status"`
+	DNSRecords []AppDNSRecordModel `tfsdk:"dns_records"`
+}
+
+// AppDNSRecordModel describes a DNS record.
+type AppDNSRecordModel struct {
+	Type  types.String `tfsdk:"type"`
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+// appDomainVerificationAttributeTypes describes the attribute types of
+// AppDomainVerificationModel.
+var appDomainVerificationAttributeTypes = map[string]attr.Type{
+	"status": types.StringType,
+	"dns_records": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"type":  types.StringType,
+		"name":  types.StringType,
+		"value": types.StringType,
+	}}},
+}
+
+// appDiskAttributeTypes describes the attribute types of AppDiskModel.
+var appDiskAttributeTypes = map[string]attr.Type{
+	"name":       types.StringType,
+	"mount_path": types.StringType,
+	"size_gb":    types.Int64Type,
+}
+
+// appTimeoutsOpts selects the operations of the timeouts block, they
+// default to the provider timeout.
+var appTimeoutsOpts = timeouts.Opts{
+	Create:            true,
+	Update:            true,
+	Delete:            true,
+	CreateDescription: "maximum duration of creating the app, e.g. `30m` (default: the provider timeout)",
+	UpdateDescription: "maximum duration of updating the app, e.g. `30m` (default: the provider timeout)",
+	DeleteDescription: "maximum duration of deleting the app, e.g. `30m` (default: the provider timeout)",
+}
+
+// appTimeoutsNull is the value of an unset timeouts block.
+var appTimeoutsNull = timeouts.Value{
+	Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}),
+}
+
+// createAppRequestBody extends the generated create app payload with the
+// bundle plan id, which is accepted by the API but missing from its spec.
+type createAppRequestBody struct {
+	paas.CreateAppJSONRequestBody
+
+	// BundlePlanID is omitted when not set, so the API picks its default.
+	BundlePlanID *string `json:"bundlePlanID,omitempty"`
+}
+
+// appPlatforms lists the platforms supported by Liara.
+var appPlatforms = []string{
+	"angular",
+	"django",
+	"docker",
+	"dotnet",
+	"flask",
+	"go",
+	"laravel",
+	"netcore",
+	"nextjs",
+	"node",
+	"php",
+	"python",
+	"react",
+	"static",
+	"vue",
+}
+
+// appReadyPollInterval is the wait between two checks of a new app.
+var appReadyPollInterval = 2 * time.Second
+
+// envKeysValidator rejects env keys which Liara doesn't accept.
+var envKeysValidator = mapvalidator.KeysAre(
+	stringvalidator.RegexMatches(envKeyPattern, "must start with a letter or an underscore, followed by letters, digits and underscores"),
+)
+
+// appStaticIPPollInterval is the initial wait between two checks of the
+// static ip, it is doubled after each check up to appStaticIPMaxPollInterval.
+var (
+	appStaticIPPollInterval    = 1 * time.Second
+	appStaticIPMaxPollInterval = 30 * time.Second
+)
+
+// appProvisioningStatuses lists the statuses of an app which is still being
+// provisioned.
+var appProvisioningStatuses = []string{
+	"CREATING",
 }
 
 func (r *AppResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,6 +209,7 @@ func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "App resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -80,8 +232,14 @@ func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Optional:            true,
 			},
 			"platform": schema.StringAttribute{
-				MarkdownDescription: "platform",
+				MarkdownDescription: "platform, changing it forces a new app to be created",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(appPlatforms...),
+				},
 			},
 			"read_only_root_filesystem": schema.BoolAttribute{
 				MarkdownDescription: "read only root filesystem",
@@ -91,24 +249,72 @@ func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				MarkdownDescription: "network name",
 				Optional:            true,
 			},
-			"rolling_update": schema.BoolAttribute{
-				MarkdownDescription: "rolling update",
+			"zero_downtime": schema.BoolAttribute{
+				MarkdownDescription: "deploy and restart the app without downtime, by starting the new instances before stopping the old ones (no effect when turn_off is true)",
+				Optional:            true,
+			},
+			"restart_trigger": schema.StringAttribute{
+				MarkdownDescription: "arbitrary value, the app is restarted whenever it changes. Set it to e.g. `timestamp()` or `uuid()` to restart the app on every apply",
 				Optional:            true,
 			},
 			"turn_off": schema.BoolAttribute{
 				MarkdownDescription: "is the app should be turned off or not (true for turn off, false for turning on)",
 				Optional:            true,
 			},
+			"scale": schema.Int64Attribute{
+				MarkdownDescription: "number of instances, at least 1. While turn_off is true the app has no instances, and it is turned back on with this scale",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"envs": schema.MapAttribute{
-				MarkdownDescription: "environment variables",
+				MarkdownDescription: "environment variables, shown in the plan output. Use `secret_envs` for sensitive values",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					envKeysValidator,
+				},
+			},
+			"secret_envs": schema.MapAttribute{
+				MarkdownDescription: "sensitive environment variables, hidden in the plan output. Keys must not be set in `envs` or `secret_envs_wo` too",
 				Optional:            true,
 				ElementType:         types.StringType,
 				Sensitive:           true,
+				Validators: []validator.Map{
+					envKeysValidator,
+				},
 			},
-			"static_ip": schema.StringAttribute{
-				MarkdownDescription: "static ip",
+			"secret_envs_wo": schema.MapAttribute{
+				MarkdownDescription: "write-only sensitive environment variables, sent to Liara but never stored in the state (requires Terraform 1.11 or later). Keys must not be set in `envs` or `secret_envs` too",
+				Optional:            true,
+				WriteOnly:           true,
+				ElementType:         types.StringType,
+				Sensitive:           true,
+				Validators: []validator.Map{
+					envKeysValidator,
+				},
+			},
+			"secret_envs_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "change it to send the values of `secret_envs_wo` again, as changes to write-only values aren't detected",
 				Optional:            true,
 			},
+			"secret_envs_wo_keys": schema.SetAttribute{
+				MarkdownDescription: "keys of `secret_envs_wo`, which are left out of `envs` when the app is read",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"static_ip": schema.StringAttribute{
+				MarkdownDescription: "static ip assigned to the app, set `enable_static_ip` to get one",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"enable_static_ip": schema.BoolAttribute{
 				MarkdownDescription: "enable static ip",
 				Optional:            true,
@@ -117,6 +323,114 @@ func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				MarkdownDescription: "disable default subdomain",
 				Optional:            true,
 			},
+			"disks": schema.ListNestedAttribute{
+				MarkdownDescription: "disks attached to the app, disks are resized in place when their size changes",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "disk name",
+							Required:            true,
+						},
+						"mount_path": schema.StringAttribute{
+							MarkdownDescription: "path the disk is mounted to, empty until it is mounted. The disk API doesn't take a mount path, disks are mounted by the `disks` section of the deployed `liara.json`",
+							Computed:            true,
+						},
+						"size_gb": schema.Int64Attribute{
+							MarkdownDescription: "disk size in GB",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"domains": schema.SetAttribute{
+				MarkdownDescription: "custom domains (hostnames) attached to the app",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "wait for the app to be provisioned after it is created, before configuring it (default: true)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "adopt an existing app with the same name instead of failing to create it, its settings, envs, disks and domains are then updated to match the configuration, as on an update",
+				Optional:            true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "refuse to delete the app, it must be set to false and applied before the app can be destroyed (default: false)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"hourly_price": schema.Float64Attribute{
+				MarkdownDescription: "hourly price",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_deployed": schema.BoolAttribute{
+				MarkdownDescription: "whether the app has been deployed",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "app status",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "creation time",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain_verifications": schema.MapNestedAttribute{
+				MarkdownDescription: "verification status of the custom domains, keyed by hostname",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"status": schema.StringAttribute{
+							MarkdownDescription: "verification status",
+							Computed:            true,
+						},
+						"dns_records": schema.ListNestedAttribute{
+							MarkdownDescription: "DNS records which must exist for the domain to be verified",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										MarkdownDescription: "record type",
+										Computed:            true,
+									},
+									"name": schema.StringAttribute{
+										MarkdownDescription: "record name",
+										Computed:            true,
+									},
+									"value": schema.StringAttribute{
+										MarkdownDescription: "record value",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, appTimeoutsOpts),
 		},
 	}
 }
@@ -137,50 +451,170 @@ func (r *AppResource) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 
-	paasClient, err := paas.NewClient(
-		providerData.APIEndpoint,
-		paas.WithHTTPClient(providerData.HTTPClient),
-		paas.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", providerData.AccessToken))
-			return nil
-		}),
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create PAAS client",
-			fmt.Sprintf("Expected paas.ClientInterface, got: %T. Please report this issue to the provider developers.", err),
+	r.client = providerData.PaasClient
+	r.timeout = providerData.Timeout
+	r.clock = providerData.Clock
+}
+
+func (r *AppResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var envs, secretEnvs, writeOnlyEnvs types.Map
+	var zeroDowntime, turnOff types.Bool
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("envs"), &envs)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_envs"), &secretEnvs)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_envs_wo"), &writeOnlyEnvs)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zero_downtime"), &zeroDowntime)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("turn_off"), &turnOff)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a turned off app has no instances to replace, zero downtime only
+	// applies once it is turned on again.
+	if zeroDowntime.ValueBool() && turnOff.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("zero_downtime"),
+			"Zero downtime has no effect on a turned off app",
+			"The app is turned off, so there are no running instances to keep up. Zero downtime applies once the app is turned on again.",
 		)
+	}
+
+	if envs.IsUnknown() || secretEnvs.IsUnknown() || writeOnlyEnvs.IsUnknown() {
+		return
+	}
+
+	setIn := make(map[string]string)
+	for _, attribute := range appEnvAttributes(envs, secretEnvs, writeOnlyEnvs) {
+		for key := range attribute.envs.Elements() {
+			if name, ok := setIn[key]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute.name).AtMapKey(key),
+					"Conflicting env",
+					fmt.Sprintf("The env %s is set in both %s and %s, it must only be set in one of them.", key, name, attribute.name),
+				)
+
+				continue
+			}
+
+			setIn[key] = attribute.name
+		}
+	}
+}
+
+// appEnvAttribute is an attribute holding envs of an app.
+type appEnvAttribute struct {
+	name string
+	envs types.Map
+}
+
+// appEnvAttributes returns the attributes holding the envs of an app, in
+// the order their conflicts are reported in.
+func appEnvAttributes(envs types.Map, secretEnvs types.Map, writeOnlyEnvs types.Map) []appEnvAttribute {
+	return []appEnvAttribute{
+		{name: "envs", envs: envs},
+		{name: "secret_envs", envs: secretEnvs},
+		{name: "secret_envs_wo", envs: writeOnlyEnvs},
+	}
+}
+
+// ModifyPlan marks the static ip as unknown when the static ip is enabled or
+// disabled, as it is only known once the change is applied. Likewise, an
+// unconfigured scale is marked as unknown when the app is turned off or on.
+func (r *AppResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// the write-only envs are only in the configuration, their keys are
+	// planned from it.
+	writeOnlyEnvs := configuredWriteOnlyEnvs(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_envs_wo_keys"), writeOnlyEnvKeys(writeOnlyEnvs))...)
 
+	// nothing else to do on create.
+	if req.State.Raw.IsNull() {
 		return
 	}
 
-	r.client = paasClient
+	var planned, prior, plannedTurnOff, priorTurnOff types.Bool
+	var configuredScale types.Int64
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("enable_static_ip"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("enable_static_ip"), &prior)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("turn_off"), &plannedTurnOff)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("turn_off"), &priorTurnOff)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("scale"), &configuredScale)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !planned.IsNull() && !planned.IsUnknown() && planned.ValueBool() != prior.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("static_ip"), types.StringUnknown())...)
+	}
+
+	if configuredScale.IsNull() && plannedTurnOff.ValueBool() != priorTurnOff.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("scale"), types.Int64Unknown())...)
+	}
 }
 
 func (r *AppResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var configuredTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &configuredTimeouts)...)
+	createTimeout, diags := configuredTimeouts.Create(ctx, r.timeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withOperationTimeout(ctx, createTimeout, &resp.Diagnostics)
+	defer done()
+
 	var data AppResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	data.SecretEnvsWO = configuredWriteOnlyEnvs(ctx, req.Config, &resp.Diagnostics)
+	data.SecretEnvsWOKeys = writeOnlyEnvKeys(data.SecretEnvsWO)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	response, err := r.client.CreateApp(ctx, paas.CreateAppJSONRequestBody{
-		Name:   data.Name.ValueStringPointer(),
-		PlanID: data.PlanID.ValueStringPointer(),
-		//BundlePlanID:           data.BundlePlanID.ValueStringPointer(),
-		Platform:               data.Platform.ValueStringPointer(),
-		ReadOnlyRootFilesystem: data.ReadOnlyRootFilesystem.ValueBoolPointer(),
+	payload, err := json.Marshal(createAppRequestBody{
+		CreateAppJSONRequestBody: paas.CreateAppJSONRequestBody{
+			Name:                   data.Name.ValueStringPointer(),
+			PlanID:                 data.PlanID.ValueStringPointer(),
+			Platform:               data.Platform.ValueStringPointer(),
+			ReadOnlyRootFilesystem: data.ReadOnlyRootFilesystem.ValueBoolPointer(),
+		},
+		BundlePlanID: data.BundlePlanID.ValueStringPointer(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("App creation failed", fmt.Sprintf("Unable to create app, got error: %s", err))
+		resp.Diagnostics.AddError("Encoding create request failed", fmt.Sprintf("Unable to encode create request, got error: %s", err))
 		return
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
+	response, err := r.client.CreateAppWithBody(ctx, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		resp.Diagnostics.AddError("Creating app failed", fmt.Sprintf("Unable to create app, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	// an adopted app already has settings, disks and domains, so it is
+	// reconciled from its current state instead of configured as new.
+	var prior *AppResourceModel
+	if response.StatusCode == http.StatusConflict && data.AdoptExisting.ValueBool() {
+		prior = r.adoptApp(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if response.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(response.Body)
 		if err != nil {
 			resp.Diagnostics.AddError("reading response payload failed", err.Error())
@@ -188,37 +622,55 @@ func (r *AppResource) Create(ctx context.Context, req resource.CreateRequest, re
 			return
 		}
 
-		resp.Diagnostics.AddError("App creation failed", fmt.Sprintf("Unable to create app, got error: %s", string(body)))
+		resp.Diagnostics.AddError("Creating app failed", fmt.Sprintf("Unable to create app, got error: %s", responseErrorMessage(response, body)))
 		return
 	}
 
 	tflog.Trace(ctx, "created an app resource")
 
-	if data.TurnOff.ValueBool() {
-		r.turnOff(ctx, &data, &resp.Diagnostics)
+	if data.WaitForReady.ValueBool() {
+		r.waitForAppReady(ctx, data.Name.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	if data.RollingUpdate.ValueBool() {
-		r.rollingUpdate(ctx, &data, &resp.Diagnostics)
+	if prior != nil {
+		r.reconcile(ctx, &data, *prior, &resp.Diagnostics)
+	} else {
+		r.configureNewApp(ctx, &data, &resp.Diagnostics)
 	}
 
-	if !data.Envs.IsNull() {
-		r.updateEnvs(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if data.EnableStaticIP.ValueBool() {
-		r.enableStaticIP(ctx, &data, &resp.Diagnostics)
+	// fill in the computed attributes from the created app.
+	app := getApp(ctx, r.client, data.Name.ValueString(), &resp.Diagnostics)
+	if app == nil {
+		return
 	}
 
-	if data.DisableDefaultSubDomain.ValueBool() {
-		r.disableDefaultSubdomain(ctx, &data, &resp.Diagnostics)
+	data.ID = types.StringValue(app.Project.ID)
+	if data.Scale.IsUnknown() {
+		data.Scale = appScale(types.Int64Null(), app.Project.Scale)
+	}
+	if data.StaticIP.IsUnknown() {
+		data.StaticIP = optionalString(types.StringNull(), app.Project.Node.ip())
 	}
+	setAppRuntimeInfo(&data, app)
+
+	// write-only values must never be stored in the state.
+	data.SecretEnvsWO = types.MapNull(types.StringType)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AppResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
 	var data AppResourceModel
 
 	// Read Terraform prior state data into the model
@@ -228,86 +680,36 @@ func (r *AppResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	response, err := r.client.GetAppByName(ctx, data.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Reading App info failed", fmt.Sprintf("Unable to read app info, got error: %s", err))
-		return
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(response.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("reading response payload failed", err.Error())
-
-			return
+	responseModel, found := findApp(ctx, r.client, data.Name.ValueString(), &resp.Diagnostics)
+	if !found {
+		// the app was removed outside of terraform, so it's planned to be
+		// created again.
+		if !resp.Diagnostics.HasError() {
+			resp.State.RemoveResource(ctx)
 		}
 
-		resp.Diagnostics.AddError("Reading App info failed", fmt.Sprintf("Unable to read app info, got error: %s", string(body)))
 		return
 	}
 
-	responseModel := struct {
-		Project struct {
-			ID                     string `json:"_id"`
-			ProjectID              string `json:"project_id"`
-			Type                   string `json:"type"`
-			Status                 string `json:"status"`
-			DefaultSubdomain       bool   `json:"defaultSubdomain"`
-			ReadOnlyRootFilesystem bool   `json:"readOnlyRootFilesystem"`
-			ZeroDowntime           bool   `json:"zeroDowntime"`
-			Scale                  int    `json:"scale"`
-			Envs                   []struct {
-				Key       string `json:"key"`
-				Value     string `json:"value"`
-				Encrypted bool   `json:"encrypted"`
-			} `json:"envs"`
-			PlanID       string `json:"planID"`
-			BundlePlanID string `json:"bundlePlanID"`
-			Network      struct {
-				ID   string `json:"_id"`
-				Name string `json:"name"`
-			} `json:"network"`
-			FixedIPStatus string `json:"fixedIPStatus"`
-			CreatedAt     string `json:"created_at"`
-			Node          struct {
-				ID string `json:"_id"`
-				IP string `json:"IP"`
-			} `json:"node"`
-			HourlyPrice       int  `json:"hourlyPrice"`
-			IsDeployed        bool `json:"isDeployed"`
-			ReservedDiskSpace int  `json:"reservedDiskSpace"`
-		} `json:"project"`
-	}{}
+	setAppAttributes(&data, responseModel)
 
-	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
-		resp.Diagnostics.AddError("Decoding read response failed", fmt.Sprintf("Unable to decode read response, got error: %s", err))
-		return
+	// disks are only refreshed when they are managed by terraform, so disks
+	// created outside of terraform are never removed.
+	if !data.Disks.IsNull() {
+		r.readDisks(ctx, &data, true, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	envs := make(map[string]attr.Value)
-	for _, env := range responseModel.Project.Envs {
-		envs[env.Key] = types.StringValue(env.Value)
-	}
-
-	data.ID = types.StringValue(responseModel.Project.ID)
-	data.Name = types.StringValue(responseModel.Project.ID)
-	data.PlanID = types.StringValue(responseModel.Project.PlanID)
-	data.BundlePlanID = types.StringValue(responseModel.Project.BundlePlanID)
-	data.Platform = types.StringValue(responseModel.Project.Type)
-	data.ReadOnlyRootFilesystem = types.BoolValue(responseModel.Project.ReadOnlyRootFilesystem)
-	data.NetworkName = types.StringValue(responseModel.Project.Network.Name)
-	data.RollingUpdate = types.BoolValue(responseModel.Project.ZeroDowntime)
-	data.TurnOff = types.BoolValue(responseModel.Project.Scale == 0)
-	data.Envs = types.MapValueMust(types.StringType, envs)
-
-	data.EnableStaticIP = types.BoolValue(len(responseModel.Project.Node.IP) > 0)
-	if data.EnableStaticIP.ValueBool() {
-		data.StaticIP = types.StringValue(responseModel.Project.Node.IP)
+	// like disks, domains are only refreshed when managed by terraform.
+	if !data.Domains.IsNull() {
+		r.readDomains(ctx, &data, true, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	data.DisableDefaultSubDomain = types.BoolValue(!responseModel.Project.DefaultSubdomain)
-
 	tflog.Trace(ctx, "read app resource")
 
 	// Save updated data into Terraform state
@@ -315,61 +717,55 @@ func (r *AppResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 func (r *AppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data AppResourceModel
-
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-
+	var configuredTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &configuredTimeouts)...)
+	updateTimeout, diags := configuredTimeouts.Update(ctx, r.timeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	response, err := r.client.ChangePlan(ctx, data.Name.ValueString(), paas.ChangePlanJSONRequestBody{
-		PlanID: data.PlanID.String(),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("App creation failed", fmt.Sprintf("Unable to create app, got error: %s", err))
-		return
-	}
-	defer response.Body.Close()
+	ctx, done := withOperationTimeout(ctx, updateTimeout, &resp.Diagnostics)
+	defer done()
 
-	if response.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(response.Body)
-		if err != nil {
-			resp.Diagnostics.AddError("reading update response payload failed", err.Error())
+	var data, state AppResourceModel
 
-			return
-		}
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	data.SecretEnvsWO = configuredWriteOnlyEnvs(ctx, req.Config, &resp.Diagnostics)
+	data.SecretEnvsWOKeys = writeOnlyEnvKeys(data.SecretEnvsWO)
 
-		resp.Diagnostics.AddError("App creation failed", fmt.Sprintf("Unable to create app, got error: %s", string(body)))
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if data.TurnOff.ValueBool() {
-		r.turnOff(ctx, &data, &resp.Diagnostics)
-	}
-
-	if data.RollingUpdate.ValueBool() {
-		r.rollingUpdate(ctx, &data, &resp.Diagnostics)
-	}
-
-	if !data.Envs.IsNull() {
-		r.updateEnvs(ctx, &data, &resp.Diagnostics)
+	r.changePlan(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if data.EnableStaticIP.ValueBool() {
-		r.enableStaticIP(ctx, &data, &resp.Diagnostics)
-	}
+	r.reconcile(ctx, &data, state, &resp.Diagnostics)
 
-	if data.DisableDefaultSubDomain.ValueBool() {
-		r.disableDefaultSubdomain(ctx, &data, &resp.Diagnostics)
-	}
+	// write-only values must never be stored in the state.
+	data.SecretEnvsWO = types.MapNull(types.StringType)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var configuredTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("timeouts"), &configuredTimeouts)...)
+	deleteTimeout, diags := configuredTimeouts.Delete(ctx, r.timeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, done := withOperationTimeout(ctx, deleteTimeout, &resp.Diagnostics)
+	defer done()
+
 	var data AppResourceModel
 
 	// Read Terraform prior state data into the model
@@ -379,12 +775,22 @@ func (r *AppResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"App is protected from deletion",
+			fmt.Sprintf("App %s has deletion_protection enabled. Set deletion_protection to false and apply the change before destroying the app.", data.Name.ValueString()),
+		)
+
+		return
+	}
+
 	response, err := r.client.DeleteAppByName(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Deleting app failed", fmt.Sprintf("Unable to delete app, got error: %s", err))
 		return
 	}
-	defer response.Body.Close()
+	defer closeResponseBody(response.Body)
 
 	if response.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(response.Body)
@@ -394,7 +800,7 @@ func (r *AppResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 			return
 		}
 
-		resp.Diagnostics.AddError("Deleting app failed", fmt.Sprintf("Unable to delete app, got error: %s", string(body)))
+		resp.Diagnostics.AddError("Deleting app failed", fmt.Sprintf("Unable to delete app, got error: %s", responseErrorMessage(response, body)))
 
 		return
 	}
@@ -403,79 +809,392 @@ func (r *AppResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *AppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
-}
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
 
-func (r *AppResource) turnOff(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
-	response, err := r.client.TurnApp(ctx, data.Name.ValueString(), paas.TurnAppJSONRequestBody{})
-	if err != nil {
-		diagnostics.AddError("Turning off the app failed", fmt.Sprintf("Unable to turn off the app, got error: %s", err))
+	app := getApp(ctx, r.client, req.ID, &resp.Diagnostics)
+	if app == nil {
 		return
 	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(response.Body)
-		if err != nil {
-			diagnostics.AddError("reading turn-off response payload failed", err.Error())
-
-			return
-		}
-
-		diagnostics.AddError("Turning off the app failed", fmt.Sprintf("Unable to turn off the app, got error: %s", string(body)))
 
+	data := r.appState(ctx, app, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Trace(ctx, "turned off the app")
+	tflog.Trace(ctx, "imported app resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *AppResource) rollingUpdate(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
-	switchMap := map[bool]string{
-		true:  "enable",
-		false: "disable",
+// appState returns the state of an app which isn't managed yet. The disks
+// and domains attached to the app are included, but are left null when the
+// app has none.
+func (r *AppResource) appState(ctx context.Context, app *appResponseModel, diagnostics *diag.Diagnostics) AppResourceModel {
+	data := AppResourceModel{
+		WaitForReady:        types.BoolValue(true),
+		DeletionProtection:  types.BoolValue(false),
+		Envs:                types.MapNull(types.StringType),
+		SecretEnvs:          types.MapNull(types.StringType),
+		SecretEnvsWO:        types.MapNull(types.StringType),
+		SecretEnvsWOKeys:    types.SetNull(types.StringType),
+		Disks:               types.ListNull(types.ObjectType{AttrTypes: appDiskAttributeTypes}),
+		Domains:             types.SetNull(types.StringType),
+		DomainVerifications: types.MapNull(types.ObjectType{AttrTypes: appDomainVerificationAttributeTypes}),
+		Timeouts:            appTimeoutsNull,
 	}
+	setAppAttributes(&data, app)
 
-	response, err := r.client.ZeroDowntime(ctx, data.Name.ValueString(), switchMap[data.RollingUpdate.ValueBool()])
-	if err != nil {
-		diagnostics.AddError("Updating rolling-update configuration failed", fmt.Sprintf("Unable to update rolling-update configuration, got error: %s", err))
-		return
+	r.readDisks(ctx, &data, true, diagnostics)
+	r.readDomains(ctx, &data, true, diagnostics)
+	if diagnostics.HasError() {
+		return data
 	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(response.Body)
-		if err != nil {
-			diagnostics.AddError("reading rolling-update response payload failed", err.Error())
-
-			return
-		}
 
-		diagnostics.AddError("Updating rolling-update configuration failed", fmt.Sprintf("Unable to update rolling-update configuration, got error: %s", string(body)))
+	if len(data.Disks.Elements()) == 0 {
+		data.Disks = types.ListNull(types.ObjectType{AttrTypes: appDiskAttributeTypes})
+	}
 
-		return
+	if len(data.Domains.Elements()) == 0 {
+		data.Domains = types.SetNull(types.StringType)
 	}
 
-	tflog.Trace(ctx, "updated rolling-update configuration")
+	return data
 }
 
-func (r *AppResource) updateEnvs(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
-	payload := paas.UpdateEnvsJSONRequestBody{
-		Project: data.Name.ValueStringPointer(),
+func (r *AppResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 named zero_downtime rolling_update.
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				resp.DynamicValue = renameStateAttributes(req.RawState, map[string]string{
+					"rolling_update": "zero_downtime",
+				}, &resp.Diagnostics)
+			},
+		},
 	}
+}
 
-	if err := data.Envs.ElementsAs(ctx, &payload.Variables, false); err != nil {
-		diagnostics.Append(err...)
+// renameStateAttributes renames the attributes of a raw state, attributes
+// missing from it are set to null.
+func renameStateAttributes(rawState *tfprotov6.RawState, names map[string]string, diagnostics *diag.Diagnostics) *tfprotov6.DynamicValue {
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(rawState.JSON, &attributes); err != nil {
+		diagnostics.AddError("Upgrading state failed", fmt.Sprintf("Unable to decode the prior state, got error: %s", err))
+		return nil
+	}
 
+	for oldName, newName := range names {
+		if value, ok := attributes[oldName]; ok {
+			attributes[newName] = value
+			delete(attributes, oldName)
+		}
+	}
+
+	upgraded, err := json.Marshal(attributes)
+	if err != nil {
+		diagnostics.AddError("Upgrading state failed", fmt.Sprintf("Unable to encode the upgraded state, got error: %s", err))
+		return nil
+	}
+
+	return &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
+func (r *AppResource) changePlan(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
+	response, err := r.client.ChangePlan(ctx, data.Name.ValueString(), paas.ChangePlanJSONRequestBody{
+		PlanID: data.PlanID.ValueString(),
+	})
+	if err != nil {
+		diagnostics.AddError("Changing plan failed", fmt.Sprintf("Unable to change plan, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading update response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Changing plan failed", fmt.Sprintf("Unable to change plan, got error: %s", responseErrorMessage(response, body)))
+	}
+}
+
+// configureNewApp applies the settings, disks and domains of a new app, the
+// settings which are the API defaults are left as they are.
+func (r *AppResource) configureNewApp(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
+	// the envs, disks and scale each redeploy the app, so they are applied
+	// one after the other.
+	if !data.Envs.IsNull() || !data.SecretEnvs.IsNull() || !data.SecretEnvsWO.IsNull() {
+		r.updateEnvs(ctx, data, diagnostics)
+	}
+
+	if !data.Disks.IsNull() {
+		r.updateDisks(ctx, data, types.ListNull(types.ObjectType{AttrTypes: appDiskAttributeTypes}), diagnostics)
+	}
+
+	if data.TurnOff.ValueBool() {
+		r.turnOff(ctx, data, diagnostics)
+	} else if !data.Scale.IsNull() && !data.Scale.IsUnknown() {
+		r.scale(ctx, data, diagnostics)
+	}
+
+	// the other settings only change the metadata of the app and are
+	// independent of each other, so they are applied concurrently. Each step
+	// only changes its own attributes of data.
+	var steps []func(diagnostics *diag.Diagnostics)
+
+	// the zero-downtime setting of a new app is the API default, so it is
+	// only changed when configured otherwise.
+	if !data.ZeroDowntime.IsNull() {
+		steps = append(steps, func(diagnostics *diag.Diagnostics) {
+			if app := getApp(ctx, r.client, data.Name.ValueString(), diagnostics); app != nil && app.Project.ZeroDowntime != data.ZeroDowntime.ValueBool() {
+				r.zeroDowntime(ctx, data, diagnostics)
+			}
+		})
+	}
+
+	if data.EnableStaticIP.ValueBool() {
+		steps = append(steps, func(diagnostics *diag.Diagnostics) { r.enableStaticIP(ctx, data, diagnostics) })
+	}
+
+	if data.DisableDefaultSubDomain.ValueBool() {
+		steps = append(steps, func(diagnostics *diag.Diagnostics) { r.disableDefaultSubdomain(ctx, data, diagnostics) })
+	}
+
+	steps = append(steps, func(diagnostics *diag.Diagnostics) {
+		r.updateDomains(ctx, data, types.SetNull(types.StringType), diagnostics)
+	})
+
+	runConcurrently(steps, diagnostics)
+}
+
+// reconcile updates the app from the prior state to the planned one, only
+// the settings which differ are changed.
+func (r *AppResource) reconcile(ctx context.Context, data *AppResourceModel, state AppResourceModel, diagnostics *diag.Diagnostics) {
+	// the scale is unknown when the app is turned off or on without a
+	// configured scale, the app keeps the scale it had before.
+	if data.Scale.IsUnknown() {
+		data.Scale = appScale(state.Scale, 0)
+	}
+
+	if data.TurnOff.ValueBool() {
+		r.turnOff(ctx, data, diagnostics)
+	} else if state.TurnOff.ValueBool() || !data.Scale.Equal(state.Scale) {
+		r.scale(ctx, data, diagnostics)
+	}
+
+	// the prior state holds the refreshed settings, which are false when
+	// null, so the endpoints are only called when a setting changes.
+	if !data.ZeroDowntime.IsNull() && data.ZeroDowntime.ValueBool() != state.ZeroDowntime.ValueBool() {
+		r.zeroDowntime(ctx, data, diagnostics)
+	}
+
+	// removing every env from the configuration clears them, so the envs are
+	// also updated when only the prior state has some.
+	priorEnvs := len(state.Envs.Elements()) + len(state.SecretEnvs.Elements()) + len(state.SecretEnvsWOKeys.Elements())
+	if !data.Envs.IsNull() || !data.SecretEnvs.IsNull() || !data.SecretEnvsWO.IsNull() || priorEnvs > 0 {
+		r.updateEnvs(ctx, data, diagnostics)
+	}
+
+	if !data.EnableStaticIP.IsNull() && data.EnableStaticIP.ValueBool() != state.EnableStaticIP.ValueBool() {
+		r.enableStaticIP(ctx, data, diagnostics)
+	} else if data.StaticIP.IsUnknown() {
+		data.StaticIP = state.StaticIP
+	}
+
+	if !data.DisableDefaultSubDomain.IsNull() && data.DisableDefaultSubDomain.ValueBool() != state.DisableDefaultSubDomain.ValueBool() {
+		r.disableDefaultSubdomain(ctx, data, diagnostics)
+	}
+
+	if !data.Disks.IsNull() || !state.Disks.IsNull() {
+		r.updateDisks(ctx, data, state.Disks, diagnostics)
+	}
+
+	r.updateDomains(ctx, data, state.Domains, diagnostics)
+
+	if !data.RestartTrigger.IsNull() && !data.RestartTrigger.Equal(state.RestartTrigger) {
+		r.restart(ctx, data, diagnostics)
+	}
+}
+
+// adoptApp takes over an existing app with the same name. It returns the
+// state of the existing app, which the app is then reconciled from, or nil
+// if the app can't be adopted.
+func (r *AppResource) adoptApp(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) *AppResourceModel {
+	app := getApp(ctx, r.client, data.Name.ValueString(), diagnostics)
+	if app == nil {
+		return nil
+	}
+
+	if app.Project.Type != data.Platform.ValueString() {
+		diagnostics.AddAttributeError(
+			path.Root("platform"),
+			"Adopting app failed",
+			fmt.Sprintf("The existing app %s uses the %s platform, which can't be changed to %s.", data.Name.ValueString(), app.Project.Type, data.Platform.ValueString()),
+		)
+
+		return nil
+	}
+
+	if app.Project.PlanID != data.PlanID.ValueString() {
+		r.changePlan(ctx, data, diagnostics)
+	}
+
+	prior := r.appState(ctx, app, diagnostics)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	// the restart trigger only restarts an app which is already managed.
+	prior.RestartTrigger = data.RestartTrigger
+
+	tflog.Info(ctx, "adopted an existing app", map[string]any{"name": data.Name.ValueString()})
+
+	return &prior
+}
+
+func (r *AppResource) turnOff(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
+	response, err := r.client.TurnApp(ctx, data.Name.ValueString(), paas.TurnAppJSONRequestBody{
+		Scale: 0,
+	})
+	if err != nil {
+		diagnostics.AddError("Turning off the app failed", fmt.Sprintf("Unable to turn off the app, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading turn-off response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Turning off the app failed", fmt.Sprintf("Unable to turn off the app, got error: %s", responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "turned off the app")
+}
+
+func (r *AppResource) scale(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
+	response, err := r.client.TurnApp(ctx, data.Name.ValueString(), paas.TurnAppJSONRequestBody{
+		Scale: float32(data.Scale.ValueInt64()),
+	})
+	if err != nil {
+		diagnostics.AddError("Scaling the app failed", fmt.Sprintf("Unable to scale the app, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading scale response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Scaling the app failed", fmt.Sprintf("Unable to scale the app, got error: %s", responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "scaled the app")
+}
+
+func (r *AppResource) zeroDowntime(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
+	switchMap := map[bool]string{
+		true:  "enable",
+		false: "disable",
+	}
+
+	response, err := r.client.ZeroDowntime(ctx, data.Name.ValueString(), switchMap[data.ZeroDowntime.ValueBool()])
+	if err != nil {
+		diagnostics.AddError("Updating zero-downtime configuration failed", fmt.Sprintf("Unable to update zero-downtime configuration, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading zero-downtime response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Updating zero-downtime configuration failed", fmt.Sprintf("Unable to update zero-downtime configuration, got error: %s", responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "updated zero-downtime configuration")
+}
+
+func (r *AppResource) restart(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
+	response, err := r.client.RestartApp(ctx, data.Name.ValueString())
+	if err != nil {
+		diagnostics.AddError("Restarting the app failed", fmt.Sprintf("Unable to restart the app, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading restart response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Restarting the app failed", fmt.Sprintf("Unable to restart the app, got error: %s", responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "restarted the app")
+}
+
+func (r *AppResource) updateEnvs(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
+	envs, diags := mergeEnvs(ctx, data.Envs, data.SecretEnvs, data.SecretEnvsWO)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
 		return
 	}
 
+	keys := make([]string, 0, len(envs))
+	for key := range envs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	variables := make([]struct {
+		Key   *string `json:"key,omitempty"`
+		Value *string `json:"value,omitempty"`
+	}, len(keys))
+	for i, key := range keys {
+		value := envs[key]
+		variables[i].Key = &key
+		variables[i].Value = &value
+	}
+
+	payload := paas.UpdateEnvsJSONRequestBody{
+		Project:   data.Name.ValueStringPointer(),
+		Variables: &variables,
+	}
+
 	response, err := r.client.UpdateEnvs(ctx, payload)
 	if err != nil {
 		diagnostics.AddError("Updating envs failed", fmt.Sprintf("Unable to update envs, got error: %s", err))
 		return
 	}
-	defer response.Body.Close()
+	defer closeResponseBody(response.Body)
 
 	if response.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(response.Body)
@@ -485,8 +1204,68 @@ func (r *AppResource) updateEnvs(ctx context.Context, data *AppResourceModel, di
 			return
 		}
 
-		diagnostics.AddError("Updating envs failed", fmt.Sprintf("Unable to update envs, got error: %s", string(body)))
+		diagnostics.AddError("Updating envs failed", fmt.Sprintf("Unable to update envs, got error: %s", responseErrorMessage(response, body)))
+	}
+}
+
+// mergeEnvs merges the plaintext, the secret and the write-only envs of an
+// app.
+func mergeEnvs(ctx context.Context, envs types.Map, secretEnvs types.Map, writeOnlyEnvs types.Map) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	merged := map[string]string{}
+	setIn := map[string]string{}
+	for _, attribute := range appEnvAttributes(envs, secretEnvs, writeOnlyEnvs) {
+		values := map[string]string{}
+		diags.Append(attribute.envs.ElementsAs(ctx, &values, false)...)
+
+		for key, value := range values {
+			if name, ok := setIn[key]; ok {
+				diags.AddAttributeError(
+					path.Root(attribute.name).AtMapKey(key),
+					"Conflicting env",
+					fmt.Sprintf("The env %s is set in both %s and %s, it must only be set in one of them.", key, name, attribute.name),
+				)
+			}
+
+			merged[key] = value
+			setIn[key] = attribute.name
+		}
+	}
+
+	return merged, diags
+}
+
+// configuredWriteOnlyEnvs returns the write-only envs of the configuration,
+// they are null when there is no configuration to read them from.
+func configuredWriteOnlyEnvs(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) types.Map {
+	writeOnlyEnvs := types.MapNull(types.StringType)
+	if config.Raw.IsNull() {
+		return writeOnlyEnvs
+	}
+
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("secret_envs_wo"), &writeOnlyEnvs)...)
+
+	return writeOnlyEnvs
+}
+
+// writeOnlyEnvKeys returns the keys of the write-only envs, which are
+// unknown as long as the envs are.
+func writeOnlyEnvKeys(writeOnlyEnvs types.Map) types.Set {
+	if writeOnlyEnvs.IsNull() {
+		return types.SetNull(types.StringType)
 	}
+
+	if writeOnlyEnvs.IsUnknown() {
+		return types.SetUnknown(types.StringType)
+	}
+
+	keys := make([]attr.Value, 0, len(writeOnlyEnvs.Elements()))
+	for key := range writeOnlyEnvs.Elements() {
+		keys = append(keys, types.StringValue(key))
+	}
+
+	return types.SetValueMust(types.StringType, keys)
 }
 
 func (r *AppResource) enableStaticIP(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
@@ -497,10 +1276,10 @@ func (r *AppResource) enableStaticIP(ctx context.Context, data *AppResourceModel
 
 	response, err := r.client.IpStatic(ctx, data.Name.ValueString(), switchMap[data.EnableStaticIP.ValueBool()])
 	if err != nil {
-		diagnostics.AddError("Enabling static ip failed", fmt.Sprintf("Unable to enable static ip, got error: %s", err))
+		diagnostics.AddError("Updating static ip failed", fmt.Sprintf("Unable to update static ip, got error: %s", err))
 		return
 	}
-	defer response.Body.Close()
+	defer closeResponseBody(response.Body)
 
 	if response.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(response.Body)
@@ -510,12 +1289,48 @@ func (r *AppResource) enableStaticIP(ctx context.Context, data *AppResourceModel
 			return
 		}
 
-		diagnostics.AddError("Enabling static ip failed", fmt.Sprintf("Unable to enable static ip, got error: %s", string(body)))
+		diagnostics.AddError("Updating static ip failed", fmt.Sprintf("Unable to update static ip, got error: %s", responseErrorMessage(response, body)))
 
 		return
 	}
 
-	tflog.Trace(ctx, "enabled static ip")
+	tflog.Trace(ctx, "updated static ip configuration")
+
+	data.StaticIP = types.StringNull()
+	if data.EnableStaticIP.ValueBool() {
+		data.StaticIP = r.waitForStaticIP(ctx, data.Name.ValueString(), diagnostics)
+	}
+}
+
+// waitForStaticIP polls the app until its static ip is assigned, which
+// happens asynchronously after it is enabled.
+func (r *AppResource) waitForStaticIP(ctx context.Context, name string, diagnostics *diag.Diagnostics) types.String {
+	interval := appStaticIPPollInterval
+
+	for {
+		app := getApp(ctx, r.client, name, diagnostics)
+		if app == nil {
+			return types.StringNull()
+		}
+
+		if ip := app.Project.Node.ip(); len(ip) > 0 {
+			return types.StringValue(ip)
+		}
+
+		tflog.Debug(ctx, "waiting for the static ip to be assigned")
+
+		if err := sleepContext(ctx, r.clock, interval); err != nil {
+			if errors.Is(err, context.Canceled) {
+				addOperationCancelledError(diagnostics)
+				return types.StringNull()
+			}
+
+			diagnostics.AddError("Waiting for static ip failed", fmt.Sprintf("The static ip of app %s wasn't assigned in time, got error: %s", name, err))
+			return types.StringNull()
+		}
+
+		interval = min(interval*2, appStaticIPMaxPollInterval)
+	}
 }
 
 func (r *AppResource) disableDefaultSubdomain(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) {
@@ -526,10 +1341,10 @@ func (r *AppResource) disableDefaultSubdomain(ctx context.Context, data *AppReso
 
 	response, err := r.client.DefaultSubdomain(ctx, data.Name.ValueString(), switchMap[!data.DisableDefaultSubDomain.ValueBool()])
 	if err != nil {
-		diagnostics.AddError("Disabling default subdomain failed", fmt.Sprintf("Unable to disable default subdomain, got error: %s", err))
+		diagnostics.AddError("Updating default subdomain failed", fmt.Sprintf("Unable to update default subdomain, got error: %s", err))
 		return
 	}
-	defer response.Body.Close()
+	defer closeResponseBody(response.Body)
 
 	if response.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(response.Body)
@@ -539,10 +1354,724 @@ func (r *AppResource) disableDefaultSubdomain(ctx context.Context, data *AppReso
 			return
 		}
 
-		diagnostics.AddError("Disabling default subdomain failed", fmt.Sprintf("Unable to disable default subdomain, got error: %s", string(body)))
+		diagnostics.AddError("Updating default subdomain failed", fmt.Sprintf("Unable to update default subdomain, got error: %s", responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "updated default subdomain configuration")
+}
+
+// updateDisks creates, resizes and deletes disks so the app's disks match the
+// planned ones, prior holds the disks which are currently attached.
+func (r *AppResource) updateDisks(ctx context.Context, data *AppResourceModel, prior types.List, diagnostics *diag.Diagnostics) {
+	var planned, current []AppDiskModel
+
+	if !data.Disks.IsNull() {
+		diagnostics.Append(data.Disks.ElementsAs(ctx, &planned, false)...)
+	}
+
+	if !prior.IsNull() {
+		diagnostics.Append(prior.ElementsAs(ctx, &current, false)...)
+	}
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	currentSizes := make(map[string]int64, len(current))
+	for _, disk := range current {
+		currentSizes[disk.Name.ValueString()] = disk.SizeGB.ValueInt64()
+	}
+
+	plannedNames := make(map[string]bool, len(planned))
+	for _, disk := range planned {
+		name := disk.Name.ValueString()
+		plannedNames[name] = true
+
+		currentSize, exists := currentSizes[name]
+		switch {
+		case !exists:
+			r.createDisk(ctx, data, disk, diagnostics)
+		case currentSize != disk.SizeGB.ValueInt64():
+			r.resizeDisk(ctx, data, disk, diagnostics)
+		}
+	}
+
+	for _, disk := range current {
+		if !plannedNames[disk.Name.ValueString()] {
+			r.deleteDisk(ctx, data, disk, diagnostics)
+		}
+	}
+
+	if diagnostics.HasError() || data.Disks.IsNull() {
+		return
+	}
+
+	r.readDisks(ctx, data, false, diagnostics)
+}
+
+func (r *AppResource) createDisk(ctx context.Context, data *AppResourceModel, disk AppDiskModel, diagnostics *diag.Diagnostics) {
+	response, err := r.client.CreateDisk(ctx, data.Name.ValueString(), paas.CreateDiskJSONRequestBody{
+		Name: disk.Name.ValueString(),
+		Size: strconv.FormatInt(disk.SizeGB.ValueInt64(), 10),
+	})
+	if err != nil {
+		diagnostics.AddError("Creating disk failed", fmt.Sprintf("Unable to create disk %q, got error: %s", disk.Name.ValueString(), err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading create disk response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Creating disk failed", fmt.Sprintf("Unable to create disk %q, got error: %s", disk.Name.ValueString(), responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "created disk")
+}
+
+func (r *AppResource) resizeDisk(ctx context.Context, data *AppResourceModel, disk AppDiskModel, diagnostics *diag.Diagnostics) {
+	response, err := r.client.ResizeDisk(ctx, data.Name.ValueString(), disk.Name.ValueString(), paas.ResizeDiskJSONRequestBody{
+		Size: strconv.FormatInt(disk.SizeGB.ValueInt64(), 10),
+	})
+	if err != nil {
+		diagnostics.AddError("Resizing disk failed", fmt.Sprintf("Unable to resize disk %q, got error: %s", disk.Name.ValueString(), err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	// shrinking a disk below its usage is rejected by the API, the reason is
+	// surfaced as is.
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading resize disk response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Resizing disk failed", fmt.Sprintf("Unable to resize disk %q, got error: %s", disk.Name.ValueString(), responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "resized disk")
+}
+
+func (r *AppResource) deleteDisk(ctx context.Context, data *AppResourceModel, disk AppDiskModel, diagnostics *diag.Diagnostics) {
+	response, err := r.client.DeleteDisk(ctx, data.Name.ValueString(), disk.Name.ValueString())
+	if err != nil {
+		diagnostics.AddError("Deleting disk failed", fmt.Sprintf("Unable to delete disk %q, got error: %s", disk.Name.ValueString(), err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading delete disk response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Deleting disk failed", fmt.Sprintf("Unable to delete disk %q, got error: %s", disk.Name.ValueString(), responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "deleted disk")
+}
+
+// readDisks reads the app's disks from the API. When refresh is true the
+// disks in the model are replaced with the remote ones, keeping the
+// configured order and appending disks created outside of terraform.
+// Otherwise only the computed attributes of the planned disks are filled.
+func (r *AppResource) readDisks(ctx context.Context, data *AppResourceModel, refresh bool, diagnostics *diag.Diagnostics) {
+	response, err := r.client.GetDisks(ctx, data.Name.ValueString())
+	if err != nil {
+		diagnostics.AddError("Reading disks failed", fmt.Sprintf("Unable to read disks, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading disks response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Reading disks failed", fmt.Sprintf("Unable to read disks, got error: %s", responseErrorMessage(response, body)))
+
+		return
+	}
+
+	responseModel := struct {
+		Disks []struct {
+			Name string `json:"name"`
+			Size int64  `json:"size"`
+		} `json:"disks"`
+		Mounts []struct {
+			Name      string `json:"name"`
+			MountedTo string `json:"mountedTo"`
+		} `json:"mounts"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		diagnostics.AddError("Decoding disks response failed", fmt.Sprintf("Unable to decode disks response, got error: %s", err))
+		return
+	}
+
+	mounts := make(map[string]string, len(responseModel.Mounts))
+	for _, mount := range responseModel.Mounts {
+		mounts[mount.Name] = mount.MountedTo
+	}
+
+	sizes := make(map[string]int64, len(responseModel.Disks))
+	for _, disk := range responseModel.Disks {
+		sizes[disk.Name] = disk.Size
+	}
+
+	var configured []AppDiskModel
+	if !data.Disks.IsNull() && !data.Disks.IsUnknown() {
+		diagnostics.Append(data.Disks.ElementsAs(ctx, &configured, false)...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	disks := make([]AppDiskModel, 0, len(responseModel.Disks))
+	known := make(map[string]bool, len(configured))
+	for _, disk := range configured {
+		name := disk.Name.ValueString()
+		known[name] = true
+
+		size, exists := sizes[name]
+		if !refresh {
+			size = disk.SizeGB.ValueInt64()
+		} else if !exists {
+			// the disk was removed outside of terraform.
+			continue
+		}
+
+		disks = append(disks, AppDiskModel{
+			Name:      disk.Name,
+			MountPath: types.StringValue(mounts[name]),
+			SizeGB:    types.Int64Value(size),
+		})
+	}
+
+	if refresh {
+		for _, disk := range responseModel.Disks {
+			if known[disk.Name] {
+				continue
+			}
+
+			disks = append(disks, AppDiskModel{
+				Name:      types.StringValue(disk.Name),
+				MountPath: types.StringValue(mounts[disk.Name]),
+				SizeGB:    types.Int64Value(disk.Size),
+			})
+		}
+	}
+
+	list, listDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: appDiskAttributeTypes}, disks)
+	diagnostics.Append(listDiags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	data.Disks = list
+}
+
+// appDomain describes a custom domain as returned by the API.
+type appDomain struct {
+	ID          string `json:"_id"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	CNameRecord string `json:"CNameRecord"`
+}
+
+// updateDomains adds and removes custom domains so the app's domains match
+// the planned ones, then fills in their verification details. prior holds
+// the domains which are currently managed by terraform.
+func (r *AppResource) updateDomains(ctx context.Context, data *AppResourceModel, prior types.Set, diagnostics *diag.Diagnostics) {
+	if data.Domains.IsNull() {
+		data.DomainVerifications = types.MapNull(types.ObjectType{AttrTypes: appDomainVerificationAttributeTypes})
+
+		if prior.IsNull() {
+			return
+		}
+	}
+
+	var planned []string
+	if !data.Domains.IsNull() {
+		diagnostics.Append(data.Domains.ElementsAs(ctx, &planned, false)...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	current := r.getDomains(ctx, data, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	currentIDs := make(map[string]string, len(current))
+	for _, domain := range current {
+		currentIDs[domain.Name] = domain.ID
+	}
+
+	plannedNames := make(map[string]bool, len(planned))
+	for _, name := range planned {
+		plannedNames[name] = true
+
+		if _, exists := currentIDs[name]; !exists {
+			r.addDomain(ctx, data, name, diagnostics)
+		}
+	}
+
+	for name, id := range currentIDs {
+		if !plannedNames[name] {
+			r.removeDomain(ctx, name, id, diagnostics)
+		}
+	}
+
+	if diagnostics.HasError() || data.Domains.IsNull() {
+		return
+	}
+
+	r.readDomains(ctx, data, false, diagnostics)
+}
+
+func (r *AppResource) addDomain(ctx context.Context, data *AppResourceModel, name string, diagnostics *diag.Diagnostics) {
+	response, err := r.client.CreateAppDomain(ctx, paas.CreateAppDomainJSONRequestBody{
+		Name:    name,
+		Project: data.Name.ValueString(),
+		Type:    "PROJECT",
+	})
+	if err != nil {
+		diagnostics.AddError("Adding domain failed", fmt.Sprintf("Unable to add domain %q, got error: %s", name, err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading add domain response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Adding domain failed", fmt.Sprintf("Unable to add domain %q, got error: %s", name, responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "added domain")
+}
+
+func (r *AppResource) removeDomain(ctx context.Context, name string, id string, diagnostics *diag.Diagnostics) {
+	response, err := r.client.DeleteDomain(ctx, id)
+	if err != nil {
+		diagnostics.AddError("Removing domain failed", fmt.Sprintf("Unable to remove domain %q, got error: %s", name, err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading remove domain response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Removing domain failed", fmt.Sprintf("Unable to remove domain %q, got error: %s", name, responseErrorMessage(response, body)))
+
+		return
+	}
+
+	tflog.Trace(ctx, "removed domain")
+}
+
+// getDomains returns the custom domains attached to the app.
+func (r *AppResource) getDomains(ctx context.Context, data *AppResourceModel, diagnostics *diag.Diagnostics) []appDomain {
+	response, err := r.client.GetAppDomains(ctx, &paas.GetAppDomainsParams{
+		Project: data.Name.ValueString(),
+	})
+	if err != nil {
+		diagnostics.AddError("Reading domains failed", fmt.Sprintf("Unable to read domains, got error: %s", err))
+		return nil
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading domains response payload failed", err.Error())
+
+			return nil
+		}
+
+		diagnostics.AddError("Reading domains failed", fmt.Sprintf("Unable to read domains, got error: %s", responseErrorMessage(response, body)))
+
+		return nil
+	}
+
+	responseModel := struct {
+		Domains []appDomain `json:"domains"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		diagnostics.AddError("Decoding domains response failed", fmt.Sprintf("Unable to decode domains response, got error: %s", err))
+		return nil
+	}
+
+	return responseModel.Domains
+}
 
+// readDomains reads the app's custom domains and their verification details.
+// When refresh is true the domains in the model are replaced with the remote
+// ones, otherwise only the verification details of the planned domains are
+// filled.
+func (r *AppResource) readDomains(ctx context.Context, data *AppResourceModel, refresh bool, diagnostics *diag.Diagnostics) {
+	current := r.getDomains(ctx, data, diagnostics)
+	if diagnostics.HasError() {
 		return
 	}
 
-	tflog.Trace(ctx, "disabled default subdomain")
+	var planned []string
+	if !refresh {
+		diagnostics.Append(data.Domains.ElementsAs(ctx, &planned, false)...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	details := make(map[string]appDomain, len(current))
+	for _, domain := range current {
+		details[domain.Name] = domain
+	}
+
+	names := planned
+	if refresh {
+		names = make([]string, 0, len(current))
+		for _, domain := range current {
+			names = append(names, domain.Name)
+		}
+	}
+
+	verifications := make(map[string]AppDomainVerificationModel, len(names))
+	for _, name := range names {
+		domain := details[name]
+
+		verification := AppDomainVerificationModel{
+			Status:     types.StringValue(domain.Status),
+			DNSRecords: []AppDNSRecordModel{},
+		}
+
+		if len(domain.CNameRecord) > 0 {
+			verification.DNSRecords = append(verification.DNSRecords, AppDNSRecordModel{
+				Type:  types.StringValue("CNAME"),
+				Name:  types.StringValue(name),
+				Value: types.StringValue(domain.CNameRecord),
+			})
+		}
+
+		verifications[name] = verification
+	}
+
+	if refresh {
+		domains, domainsDiags := types.SetValueFrom(ctx, types.StringType, names)
+		diagnostics.Append(domainsDiags...)
+		data.Domains = domains
+	}
+
+	verificationsMap, verificationsDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: appDomainVerificationAttributeTypes}, verifications)
+	diagnostics.Append(verificationsDiags...)
+	data.DomainVerifications = verificationsMap
+}
+
+// waitForAppReady polls the app until it isn't being provisioned anymore, so
+// it can be configured right after it is created.
+func (r *AppResource) waitForAppReady(ctx context.Context, name string, diagnostics *diag.Diagnostics) {
+	for {
+		app := getApp(ctx, r.client, name, diagnostics)
+		if app == nil || !slices.Contains(appProvisioningStatuses, app.Project.Status) {
+			return
+		}
+
+		tflog.Debug(ctx, "waiting for the app to be ready", map[string]any{"status": app.Project.Status})
+
+		if err := sleepContext(ctx, r.clock, appReadyPollInterval); err != nil {
+			if errors.Is(err, context.Canceled) {
+				addOperationCancelledError(diagnostics)
+				return
+			}
+
+			diagnostics.AddError("Waiting for app failed", fmt.Sprintf("App %s wasn't ready in time, got error: %s", name, err))
+			return
+		}
+	}
+}
+
+// runConcurrently runs the steps concurrently, each with its own
+// diagnostics as diag.Diagnostics isn't safe for concurrent use. The
+// diagnostics of all the steps are appended in the order of the steps.
+func runConcurrently(steps []func(diagnostics *diag.Diagnostics), diagnostics *diag.Diagnostics) {
+	results := make([]diag.Diagnostics, len(steps))
+
+	var group errgroup.Group
+	for i, step := range steps {
+		group.Go(func() error {
+			step(&results[i])
+
+			// errors are reported as diagnostics, so a failed step doesn't
+			// stop the others.
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	for _, result := range results {
+		diagnostics.Append(result...)
+	}
+}
+
+// appResponseModel describes the app details returned by the API.
+type appResponseModel struct {
+	Project struct {
+		ID                     string `json:"_id"`
+		ProjectID              string `json:"project_id"`
+		Type                   string `json:"type"`
+		Status                 string `json:"status"`
+		DefaultSubdomain       bool   `json:"defaultSubdomain"`
+		ReadOnlyRootFilesystem bool   `json:"readOnlyRootFilesystem"`
+		ZeroDowntime           bool   `json:"zeroDowntime"`
+		Scale                  int    `json:"scale"`
+		Envs                   []struct {
+			Key       string `json:"key"`
+			Value     string `json:"value"`
+			Encrypted bool   `json:"encrypted"`
+		} `json:"envs"`
+		PlanID            string      `json:"planID"`
+		BundlePlanID      string      `json:"bundlePlanID"`
+		Network           *appNetwork `json:"network"`
+		FixedIPStatus     string      `json:"fixedIPStatus"`
+		CreatedAt         string      `json:"created_at"`
+		Node              *appNode    `json:"node"`
+		HourlyPrice       float64     `json:"hourlyPrice"`
+		IsDeployed        bool        `json:"isDeployed"`
+		ReservedDiskSpace float64     `json:"reservedDiskSpace"`
+	} `json:"project"`
+}
+
+// appNetwork is the network of an app, the API may omit it, e.g. for a
+// turned off app.
+type appNetwork struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+}
+
+// name returns the network name, or an empty string if it was omitted.
+func (n *appNetwork) name() string {
+	if n == nil {
+		return ""
+	}
+
+	return n.Name
+}
+
+// appNode is the node an app is scheduled on, the API may omit it, e.g. for
+// a turned off app.
+type appNode struct {
+	ID string `json:"_id"`
+	IP string `json:"IP"`
+}
+
+// ip returns the static ip of the node, or an empty string if it was
+// omitted.
+func (n *appNode) ip() string {
+	if n == nil {
+		return ""
+	}
+
+	return n.IP
+}
+
+// setAppAttributes sets the attributes of the app which are returned by the
+// API. Optional attributes which aren't set keep being null as long as the
+// app uses the API defaults, so they don't cause a plan diff.
+func setAppAttributes(data *AppResourceModel, app *appResponseModel) {
+	// envs which are known to be secret are kept in secret_envs. Encrypted
+	// envs are returned masked, so the known value of those is kept to
+	// prevent a perpetual plan diff.
+	priorEnvs := data.Envs.Elements()
+	priorSecretEnvs := data.SecretEnvs.Elements()
+	writeOnlyKeys := make(map[string]bool)
+	for _, key := range data.SecretEnvsWOKeys.Elements() {
+		if key, ok := key.(types.String); ok {
+			writeOnlyKeys[key.ValueString()] = true
+		}
+	}
+	envs := make(map[string]attr.Value)
+	secretEnvs := make(map[string]attr.Value)
+	for _, env := range app.Project.Envs {
+		// write-only envs are only in the configuration, so they aren't
+		// read back into any of the maps.
+		if writeOnlyKeys[env.Key] {
+			continue
+		}
+
+		target, prior := envs, priorEnvs
+		if _, ok := priorSecretEnvs[env.Key]; ok {
+			target, prior = secretEnvs, priorSecretEnvs
+		}
+
+		if priorValue, ok := prior[env.Key]; ok && env.Encrypted {
+			target[env.Key] = priorValue
+			continue
+		}
+
+		target[env.Key] = types.StringValue(env.Value)
+	}
+
+	data.ID = types.StringValue(app.Project.ID)
+	data.Name = types.StringValue(app.Project.ProjectID)
+	data.PlanID = types.StringValue(app.Project.PlanID)
+	// apps without a bundle plan have an empty bundle plan id, which is
+	// kept as null so it doesn't differ from an unset bundle_plan_id.
+	data.BundlePlanID = types.StringNull()
+	if len(app.Project.BundlePlanID) > 0 {
+		data.BundlePlanID = types.StringValue(app.Project.BundlePlanID)
+	}
+	data.Platform = types.StringValue(app.Project.Type)
+	data.ReadOnlyRootFilesystem = types.BoolValue(app.Project.ReadOnlyRootFilesystem)
+	// the network and the node are only overwritten when the API returns
+	// them, so the known values are kept otherwise.
+	if app.Project.Network != nil {
+		data.NetworkName = optionalString(data.NetworkName, app.Project.Network.Name)
+	}
+	data.ZeroDowntime = optionalBool(data.ZeroDowntime, app.Project.ZeroDowntime)
+	data.TurnOff = optionalBool(data.TurnOff, app.Project.Scale == 0)
+	data.Scale = appScale(data.Scale, app.Project.Scale)
+
+	if len(envs) > 0 || !data.Envs.IsNull() {
+		data.Envs = types.MapValueMust(types.StringType, envs)
+	}
+
+	if len(secretEnvs) > 0 || !data.SecretEnvs.IsNull() {
+		data.SecretEnvs = types.MapValueMust(types.StringType, secretEnvs)
+	}
+
+	// static_ip used to be configurable, so any configured value left in
+	// the state is replaced by the assigned one.
+	if app.Project.Node != nil {
+		data.EnableStaticIP = optionalBool(data.EnableStaticIP, len(app.Project.Node.IP) > 0)
+		data.StaticIP = optionalString(types.StringNull(), app.Project.Node.IP)
+	}
+
+	data.DisableDefaultSubDomain = optionalBool(data.DisableDefaultSubDomain, !app.Project.DefaultSubdomain)
+	setAppRuntimeInfo(data, app)
+}
+
+// optionalBool returns the value, or null if the attribute isn't set and
+// the value is the default (false).
+func optionalBool(current types.Bool, value bool) types.Bool {
+	if current.IsNull() && !value {
+		return types.BoolNull()
+	}
+
+	return types.BoolValue(value)
+}
+
+// appScale returns the scale of the app. A turned off app has no instances,
+// so it keeps the current scale instead, which it is turned back on with,
+// or 1 if there is none.
+func appScale(current types.Int64, scale int) types.Int64 {
+	if scale > 0 {
+		return types.Int64Value(int64(scale))
+	}
+
+	if current.IsNull() || current.IsUnknown() || current.ValueInt64() < 1 {
+		return types.Int64Value(1)
+	}
+
+	return current
+}
+
+// optionalString returns the value, or null if the attribute isn't set and
+// the value is empty.
+func optionalString(current types.String, value string) types.String {
+	if current.IsNull() && len(value) == 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// setAppRuntimeInfo sets the read-only runtime attributes of the app. They
+// are only refreshed on Create and Read, so they never cause a plan diff.
+func setAppRuntimeInfo(data *AppResourceModel, app *appResponseModel) {
+	data.HourlyPrice = types.Float64Value(app.Project.HourlyPrice)
+	data.IsDeployed = types.BoolValue(app.Project.IsDeployed)
+	data.Status = types.StringValue(app.Project.Status)
+	data.CreatedAt = types.StringValue(app.Project.CreatedAt)
+}
+
+// getApp fetches the app details, it returns nil if the app couldn't be read.
+// An app that doesn't exist is reported as an error.
+func getApp(ctx context.Context, client paas.ClientInterface, name string, diagnostics *diag.Diagnostics) *appResponseModel {
+	app, found := findApp(ctx, client, name, diagnostics)
+	if !found && !diagnostics.HasError() {
+		diagnostics.AddError("App not found", fmt.Sprintf("App %q does not exist", name))
+	}
+
+	return app
+}
+
+// findApp fetches the app details, it returns false if the app doesn't exist
+// or couldn't be read. Only the latter adds a diagnostic.
+func findApp(ctx context.Context, client paas.ClientInterface, name string, diagnostics *diag.Diagnostics) (*appResponseModel, bool) {
+	response, err := client.GetAppByName(ctx, name)
+	if err != nil {
+		diagnostics.AddError("Reading App info failed", fmt.Sprintf("Unable to read app info, got error: %s", err))
+		return nil, false
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, false
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading response payload failed", err.Error())
+
+			return nil, false
+		}
+
+		diagnostics.AddError("Reading App info failed", fmt.Sprintf("Unable to read app info, got error: %s", responseErrorMessage(response, body)))
+		return nil, false
+	}
+
+	var responseModel appResponseModel
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		diagnostics.AddError("Decoding read response failed", fmt.Sprintf("Unable to decode read response, got error: %s", err))
+		return nil, false
+	}
+
+	return &responseModel, true
 }
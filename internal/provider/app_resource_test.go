@@ -1,82 +1,2575 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
 )
 
-func TestAccExampleResource(t *testing.T) {
+func TestAccAppResource(t *testing.T) {
+	server := newTestPaasServer(t)
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: server.providerConfig() + `
+resource "liara_app" "test" {
+  name                      = "my-app"
+  plan_id                   = "small"
+  platform                  = "docker"
+  read_only_root_filesystem = false
+}
+`,
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
-						"liara_example.test",
+						"liara_app.test",
 						tfjsonpath.New("id"),
-						knownvalue.StringExact("example-id"),
+						knownvalue.StringExact("id-my-app"),
 					),
 					statecheck.ExpectKnownValue(
-						"liara_example.test",
-						tfjsonpath.New("defaulted"),
-						knownvalue.StringExact("example value when not configured"),
+						"liara_app.test",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("RUNNING"),
 					),
 					statecheck.ExpectKnownValue(
-						"liara_example.test",
-						tfjsonpath.New("configurable_attribute"),
-						knownvalue.StringExact("one"),
+						"liara_app.test",
+						tfjsonpath.New("scale"),
+						knownvalue.Int64Exact(1),
 					),
 				},
 			},
-			// ImportState testing
-			{
-				ResourceName:      "liara_example.test",
-				ImportState:       true,
-				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute", "defaulted"},
-			},
-			// Update and Read testing
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if names := server.appNames(); len(names) > 0 {
+				return fmt.Errorf("expected the apps to be deleted, got %v", names)
+			}
+
+			return nil
+		},
+	})
+}
+
+func TestAccAppResourceTurnOn(t *testing.T) {
+	server := newTestPaasServer(t)
+
+	config := func(turnOff bool) string {
+		return server.providerConfig() + fmt.Sprintf(`
+resource "liara_app" "test" {
+  name                      = "my-app"
+  plan_id                   = "small"
+  platform                  = "docker"
+  read_only_root_filesystem = false
+  turn_off                  = %t
+}
+`, turnOff)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// the scale is kept while the app is turned off, so the plan
+			// settles.
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: config(true),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
-						"liara_example.test",
-						tfjsonpath.New("id"),
-						knownvalue.StringExact("example-id"),
+						"liara_app.test",
+						tfjsonpath.New("scale"),
+						knownvalue.Int64Exact(1),
 					),
+				},
+			},
+			// turning the app back on restores the kept scale.
+			{
+				Config: config(false),
+				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
-						"liara_example.test",
-						tfjsonpath.New("defaulted"),
-						knownvalue.StringExact("example value when not configured"),
+						"liara_app.test",
+						tfjsonpath.New("turn_off"),
+						knownvalue.Bool(false),
 					),
 					statecheck.ExpectKnownValue(
-						"liara_example.test",
-						tfjsonpath.New("configurable_attribute"),
-						knownvalue.StringExact("two"),
+						"liara_app.test",
+						tfjsonpath.New("scale"),
+						knownvalue.Int64Exact(1),
 					),
 				},
 			},
-			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
-	return fmt.Sprintf(`
-resource "liara_app" "test" {
-  configurable_attribute = %[1]q
+func TestAppResourceReadTurnedOffKeepsScale(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":0}}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "my-app"),
+		"turn_off": tftypes.NewValue(tftypes.Bool, true),
+		"scale":    tftypes.NewValue(tftypes.Number, 3),
+	})
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if !data.TurnOff.ValueBool() || data.Scale.ValueInt64() != 3 {
+		t.Errorf("expected the turned off app to keep scale 3, got turn_off %s and scale %s", data.TurnOff, data.Scale)
+	}
+}
+
+func TestAppResourceReadKeepsName(t *testing.T) {
+	var requestedPaths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"project":{"_id":"65a1b2c3d4e5f6a7b8c9d0e1","project_id":"my-app","type":"docker","planID":"small","scale":1}}`))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "my-app"),
+	})
+
+	for i := 0; i < 2; i++ {
+		resp := fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var data AppResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if data.Name.ValueString() != "my-app" {
+			t.Errorf("read #%d: expected name my-app, got %s", i+1, data.Name.ValueString())
+		}
+
+		if data.ID.ValueString() != "65a1b2c3d4e5f6a7b8c9d0e1" {
+			t.Errorf("read #%d: expected id to be the project _id, got %s", i+1, data.ID.ValueString())
+		}
+
+		state = resp.State
+	}
+
+	for _, requestedPath := range requestedPaths {
+		if requestedPath != "/v1/projects/my-app" {
+			t.Errorf("expected the app to be read by name, got request to %s", requestedPath)
+		}
+	}
+}
+
+func TestAppResourceReadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client, timeout: 50 * time.Millisecond}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "my-app"),
+	})
+
+	start := time.Now()
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the read to abort after the timeout, took %s", elapsed)
+	}
+
+	var timedOut bool
+	for _, d := range resp.Diagnostics.Errors() {
+		if d.Summary() == "Operation timed out" {
+			timedOut = true
+		}
+	}
+
+	if !timedOut {
+		t.Errorf("expected a timeout diagnostic, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestAppResourceImportState(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{
+			"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,
+			"envs":[{"key":"A","value":"1"}],"zeroDowntime":true,"defaultSubdomain":false,
+			"node":{"_id":"n1","IP":"1.2.3.4"}
+		}}`,
+		getDisksBody:      `{"disks":[],"mounts":[]}`,
+		getAppDomainsBody: `{"domains":[]}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	resp := fwresource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "my-app"}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	// the configuration matching the imported app must produce an empty plan,
+	// so every configurable attribute has to match it.
+	config := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"envs": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"A": tftypes.NewValue(tftypes.String, "1"),
+		}),
+		"zero_downtime":             tftypes.NewValue(tftypes.Bool, true),
+		"enable_static_ip":          tftypes.NewValue(tftypes.Bool, true),
+		"disable_default_subdomain": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	var imported, configured map[string]tftypes.Value
+	if err := resp.State.Raw.As(&imported); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Raw.As(&configured); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, attribute := range schemaResp.Schema.Attributes {
+		if attribute.IsComputed() && configured[name].IsNull() {
+			continue
+		}
+
+		if !imported[name].Equal(configured[name]) {
+			t.Errorf("expected %s to be imported as %s, got %s", name, configured[name], imported[name])
+		}
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.ID.ValueString() != "id" || data.StaticIP.ValueString() != "1.2.3.4" {
+		t.Errorf("expected computed attributes to be imported, got id %s and static_ip %s", data.ID, data.StaticIP)
+	}
+}
+
+func TestAppResourceImportStateNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"project not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	resp := fwresource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "missing-app"}, &resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "App not found" {
+		t.Errorf("expected an app not found diagnostic, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestAppResourceReadNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"project not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "my-app"),
+	})
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected the app to be removed from the state, got %s", resp.State.Raw)
+	}
+}
+
+func TestAppResourceReadRuntimeInfo(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"status":"RUNNING","isDeployed":true,"hourlyPrice":12.5,"created_at":"2024-01-02T03:04:05.000Z"}}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "my-app"),
+	})
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if data.Status.ValueString() != "RUNNING" {
+		t.Errorf("expected status RUNNING, got %s", data.Status)
+	}
+
+	if !data.IsDeployed.ValueBool() {
+		t.Errorf("expected is_deployed to be true, got %s", data.IsDeployed)
+	}
+
+	if data.HourlyPrice.ValueFloat64() != 12.5 {
+		t.Errorf("expected hourly_price 12.5, got %s", data.HourlyPrice)
+	}
+
+	if data.CreatedAt.ValueString() != "2024-01-02T03:04:05.000Z" {
+		t.Errorf("expected created_at to be set, got %s", data.CreatedAt)
+	}
+}
+
+func TestAppResourceReadOmittedNetworkAndNode(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":0}}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":             tftypes.NewValue(tftypes.String, "my-app"),
+		"network_name":     tftypes.NewValue(tftypes.String, "my-network"),
+		"enable_static_ip": tftypes.NewValue(tftypes.Bool, true),
+		"static_ip":        tftypes.NewValue(tftypes.String, "1.2.3.4"),
+	})
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if data.NetworkName.ValueString() != "my-network" {
+		t.Errorf("expected network_name to be preserved, got %s", data.NetworkName)
+	}
+
+	if !data.EnableStaticIP.ValueBool() || data.StaticIP.ValueString() != "1.2.3.4" {
+		t.Errorf("expected the static ip to be preserved, got %s (enabled: %s)", data.StaticIP, data.EnableStaticIP)
+	}
+}
+
+func TestAppResourceReadEmptyBundlePlanID(t *testing.T) {
+	testCases := []struct {
+		name         string
+		bundlePlanID tftypes.Value
+	}{
+		{name: "unset", bundlePlanID: tftypes.NewValue(tftypes.String, nil)},
+		{name: "removed outside of terraform", bundlePlanID: tftypes.NewValue(tftypes.String, "standard")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{
+				getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","bundlePlanID":"","scale":1}}`,
+			}
+
+			ctx := context.Background()
+			r := &AppResource{client: client}
+
+			state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+				"name":           tftypes.NewValue(tftypes.String, "my-app"),
+				"bundle_plan_id": tc.bundlePlanID,
+			})
+
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data AppResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			// a null bundle_plan_id matches an unset one in the configuration,
+			// so it doesn't cause a plan diff.
+			if !data.BundlePlanID.IsNull() {
+				t.Errorf("expected bundle_plan_id to be null, got %s", data.BundlePlanID)
+			}
+		})
+	}
+}
+
+func TestAppResourceReadEncryptedEnvs(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"envs":[{"key":"PORT","value":"8080","encrypted":false},{"key":"SECRET","value":"********","encrypted":true}]}}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	envsType := tftypes.Map{ElementType: tftypes.String}
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "my-app"),
+		"envs": tftypes.NewValue(envsType, map[string]tftypes.Value{
+			"SECRET": tftypes.NewValue(tftypes.String, "plaintext"),
+			"PORT":   tftypes.NewValue(tftypes.String, "8080"),
+		}),
+	})
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	envs := map[string]string{}
+	resp.Diagnostics.Append(data.Envs.ElementsAs(ctx, &envs, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	expected := map[string]string{"SECRET": "plaintext", "PORT": "8080"}
+	if !reflect.DeepEqual(envs, expected) {
+		t.Errorf("expected envs %v, got %v", expected, envs)
+	}
+}
+
+func TestAppResourceUpdateMergesEnvs(t *testing.T) {
+	client := &fakePaasClient{}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	envsType := tftypes.Map{ElementType: tftypes.String}
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"envs": tftypes.NewValue(envsType, map[string]tftypes.Value{
+			"PORT": tftypes.NewValue(tftypes.String, "8080"),
+		}),
+		"secret_envs": tftypes.NewValue(envsType, map[string]tftypes.Value{
+			"API_KEY": tftypes.NewValue(tftypes.String, "secret"),
+		}),
+	})
+	plan := tfsdk.Plan(state)
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(client.updateEnvsBodies) != 1 {
+		t.Fatalf("expected UpdateEnvs to be called once, got %d calls", len(client.updateEnvsBodies))
+	}
+
+	envs := map[string]string{}
+	for _, variable := range *client.updateEnvsBodies[0].Variables {
+		envs[*variable.Key] = *variable.Value
+	}
+
+	expected := map[string]string{"PORT": "8080", "API_KEY": "secret"}
+	if !reflect.DeepEqual(envs, expected) {
+		t.Errorf("expected envs %v, got %v", expected, envs)
+	}
+}
+
+func TestAppResourceUpdateEnvsVariables(t *testing.T) {
+	client := &fakePaasClient{}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	envsType := tftypes.Map{ElementType: tftypes.String}
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"envs": tftypes.NewValue(envsType, map[string]tftypes.Value{
+			"PORT":      tftypes.NewValue(tftypes.String, "8080"),
+			"LOG_LEVEL": tftypes.NewValue(tftypes.String, "debug"),
+		}),
+	})
+	plan := tfsdk.Plan(state)
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(client.updateEnvsBodies) != 1 || client.updateEnvsBodies[0].Variables == nil {
+		t.Fatalf("expected UpdateEnvs to be called once with variables, got %v", client.updateEnvsBodies)
+	}
+
+	// the variables are sorted by key, so the request body is stable.
+	var variables []string
+	for _, variable := range *client.updateEnvsBodies[0].Variables {
+		variables = append(variables, *variable.Key+"="+*variable.Value)
+	}
+
+	if expected := []string{"LOG_LEVEL=debug", "PORT=8080"}; !reflect.DeepEqual(variables, expected) {
+		t.Errorf("expected variables %v, got %v", expected, variables)
+	}
+}
+
+func TestAppResourceUpdateRemoveAllEnvs(t *testing.T) {
+	client := &fakePaasClient{}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	envsType := tftypes.Map{ElementType: tftypes.String}
+	attributes := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"envs": tftypes.NewValue(envsType, map[string]tftypes.Value{
+			"PORT": tftypes.NewValue(tftypes.String, "8080"),
+		}),
+		"secret_envs": tftypes.NewValue(envsType, map[string]tftypes.Value{
+			"SECRET": tftypes.NewValue(tftypes.String, "s3cr3t"),
+		}),
+	}
+	state := testAppResourceState(ctx, t, r, attributes)
+
+	delete(attributes, "envs")
+	delete(attributes, "secret_envs")
+	plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(client.updateEnvsBodies) != 1 || client.updateEnvsBodies[0].Variables == nil {
+		t.Fatalf("expected UpdateEnvs to be called once with variables, got %v", client.updateEnvsBodies)
+	}
+
+	if variables := *client.updateEnvsBodies[0].Variables; len(variables) != 0 {
+		t.Errorf("expected the envs to be cleared, got %d variables", len(variables))
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if !data.Envs.IsNull() || !data.SecretEnvs.IsNull() {
+		t.Errorf("expected the envs to stay null, got envs %s and secret envs %s", data.Envs, data.SecretEnvs)
+	}
+}
+
+func TestAppResourceUpdateWriteOnlyEnvs(t *testing.T) {
+	client := &fakePaasClient{}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	envsType := tftypes.Map{ElementType: tftypes.String}
+	attributes := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"envs": tftypes.NewValue(envsType, map[string]tftypes.Value{
+			"PORT": tftypes.NewValue(tftypes.String, "8080"),
+		}),
+	}
+	state := testAppResourceState(ctx, t, r, attributes)
+	// write-only values are only in the configuration, never in the plan.
+	plan := tfsdk.Plan(state)
+
+	attributes["secret_envs_wo"] = tftypes.NewValue(envsType, map[string]tftypes.Value{
+		"API_KEY": tftypes.NewValue(tftypes.String, "secret"),
+	})
+	config := tfsdk.Config(testAppResourceState(ctx, t, r, attributes))
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Config: config, Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(client.updateEnvsBodies) != 1 {
+		t.Fatalf("expected UpdateEnvs to be called once, got %d calls", len(client.updateEnvsBodies))
+	}
+
+	envs := map[string]string{}
+	for _, variable := range *client.updateEnvsBodies[0].Variables {
+		envs[*variable.Key] = *variable.Value
+	}
+
+	expected := map[string]string{"PORT": "8080", "API_KEY": "secret"}
+	if !reflect.DeepEqual(envs, expected) {
+		t.Errorf("expected envs %v, got %v", expected, envs)
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if !data.SecretEnvsWO.IsNull() {
+		t.Errorf("expected secret_envs_wo to be left out of the state, got %s", data.SecretEnvsWO)
+	}
+
+	if _, ok := data.Envs.Elements()["API_KEY"]; ok {
+		t.Errorf("expected the write-only env to be left out of envs, got %s", data.Envs)
+	}
+
+	keys := []string{}
+	resp.Diagnostics.Append(data.SecretEnvsWOKeys.ElementsAs(ctx, &keys, false)...)
+	if !reflect.DeepEqual(keys, []string{"API_KEY"}) {
+		t.Errorf("expected secret_envs_wo_keys [API_KEY], got %v", keys)
+	}
+}
+
+func TestAppResourceValidateConfigConflictingEnvs(t *testing.T) {
+	ctx := context.Background()
+	r := &AppResource{}
+
+	envsType := tftypes.Map{ElementType: tftypes.String}
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "my-app"),
+		"envs": tftypes.NewValue(envsType, map[string]tftypes.Value{
+			"PORT":    tftypes.NewValue(tftypes.String, "8080"),
+			"API_KEY": tftypes.NewValue(tftypes.String, "visible"),
+		}),
+		"secret_envs": tftypes.NewValue(envsType, map[string]tftypes.Value{
+			"API_KEY": tftypes.NewValue(tftypes.String, "secret"),
+		}),
+	})
+
+	resp := fwresource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: tfsdk.Config(state)}, &resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got: %v", resp.Diagnostics)
+	}
+
+	expectedPath := path.Root("secret_envs").AtMapKey("API_KEY")
+	if diagnostic, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath); !ok || !diagnostic.Path().Equal(expectedPath) {
+		t.Errorf("expected an error on %s, got: %v", expectedPath, resp.Diagnostics)
+	}
+}
+
+func TestAppResourceValidateConfigZeroDowntimeTurnedOff(t *testing.T) {
+	ctx := context.Background()
+	r := &AppResource{}
+
+	testCases := []struct {
+		name          string
+		turnOff       bool
+		expectWarning bool
+	}{
+		{name: "turned off", turnOff: true, expectWarning: true},
+		{name: "turned on", turnOff: false, expectWarning: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+				"name":          tftypes.NewValue(tftypes.String, "my-app"),
+				"zero_downtime": tftypes.NewValue(tftypes.Bool, true),
+				"turn_off":      tftypes.NewValue(tftypes.Bool, tc.turnOff),
+			})
+
+			resp := fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: tfsdk.Config(state)}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			if warned := resp.Diagnostics.WarningsCount() == 1; warned != tc.expectWarning {
+				t.Fatalf("expected warning %t, got: %v", tc.expectWarning, resp.Diagnostics)
+			}
+
+			if tc.expectWarning {
+				expectedPath := path.Root("zero_downtime")
+				if diagnostic, ok := resp.Diagnostics.Warnings()[0].(diag.DiagnosticWithPath); !ok || !diagnostic.Path().Equal(expectedPath) {
+					t.Errorf("expected a warning on %s, got: %v", expectedPath, resp.Diagnostics)
+				}
+			}
+		})
+	}
+}
+
+func TestAppResourceUpdateSendsUnquotedPlanID(t *testing.T) {
+	client := &fakePaasClient{}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	attributes := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "power"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+	}
+	state := testAppResourceState(ctx, t, r, attributes)
+	plan := tfsdk.Plan(state)
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(client.changePlanBodies) != 1 {
+		t.Fatalf("expected ChangePlan to be called once, got %d calls", len(client.changePlanBodies))
+	}
+
+	if planID := client.changePlanBodies[0].PlanID; planID != "power" {
+		t.Errorf("expected plan id power, got %s", planID)
+	}
+}
+
+func TestAppResourceDeleteProtection(t *testing.T) {
+	testCases := []struct {
+		name               string
+		deletionProtection bool
+		expectDeleted      []string
+	}{
+		{
+			name:               "protected",
+			deletionProtection: true,
+		},
+		{
+			name:               "unprotected",
+			deletionProtection: false,
+			expectDeleted:      []string{"my-app"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{}
+
+			ctx := context.Background()
+			r := &AppResource{client: client}
+
+			state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+				"name":                tftypes.NewValue(tftypes.String, "my-app"),
+				"deletion_protection": tftypes.NewValue(tftypes.Bool, tc.deletionProtection),
+			})
+
+			resp := fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() != tc.deletionProtection {
+				t.Errorf("expected an error %t, got: %v", tc.deletionProtection, resp.Diagnostics)
+			}
+
+			if !reflect.DeepEqual(client.deletedApps, tc.expectDeleted) {
+				t.Errorf("expected deleted apps %v, got %v", tc.expectDeleted, client.deletedApps)
+			}
+		})
+	}
+}
+
+func TestAppResourceUpdateChangePlanError(t *testing.T) {
+	client := &fakePaasClient{failingMethods: []string{"ChangePlan"}}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "power"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+	})
+	plan := tfsdk.Plan(state)
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got: %v", resp.Diagnostics)
+	}
+
+	summary := resp.Diagnostics.Errors()[0].Summary()
+	if !strings.Contains(summary, "plan") || strings.Contains(summary, "creation") {
+		t.Errorf("expected the error to be about the plan change, got %q", summary)
+	}
+}
+
+func TestAppResourceCreateSendsBundlePlanID(t *testing.T) {
+	testCases := []struct {
+		name         string
+		bundlePlanID tftypes.Value
+		expected     any
+	}{
+		{
+			name:         "bundle plan id set",
+			bundlePlanID: tftypes.NewValue(tftypes.String, "standard"),
+			expected:     "standard",
+		},
+		{
+			name:         "bundle plan id null",
+			bundlePlanID: tftypes.NewValue(tftypes.String, nil),
+			expected:     nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{
+				getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","bundlePlanID":"standard","scale":1}}`,
+			}
+
+			ctx := context.Background()
+			r := &AppResource{client: client}
+
+			state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+				"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+				"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+				"bundle_plan_id":            tc.bundlePlanID,
+				"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+				"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+			})
+
+			createResp := fwresource.CreateResponse{State: state}
+			r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(state)}, &createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+			}
+
+			if len(client.createAppBodies) != 1 {
+				t.Fatalf("expected CreateApp to be called once, got %d calls", len(client.createAppBodies))
+			}
+
+			bundlePlanID, ok := client.createAppBodies[0]["bundlePlanID"]
+			if tc.expected == nil && ok {
+				t.Errorf("expected bundlePlanID to be omitted, got %v", bundlePlanID)
+			}
+			if tc.expected != nil && bundlePlanID != tc.expected {
+				t.Errorf("expected bundlePlanID %v, got %v", tc.expected, bundlePlanID)
+			}
+
+			if tc.expected == nil {
+				return
+			}
+
+			readResp := fwresource.ReadResponse{State: createResp.State}
+			r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, &readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+			}
+
+			var data AppResourceModel
+			readResp.Diagnostics.Append(readResp.State.Get(ctx, &data)...)
+			if data.BundlePlanID.ValueString() != tc.expected {
+				t.Errorf("expected bundle_plan_id %v after read, got %s", tc.expected, data.BundlePlanID)
+			}
+		})
+	}
+}
+
+func TestAppResourceCreateWaitsForReady(t *testing.T) {
+	testCases := []struct {
+		name            string
+		waitForReady    bool
+		expectedPending int
+	}{
+		{name: "wait for ready", waitForReady: true, expectedPending: 0},
+		{name: "don't wait for ready", waitForReady: false, expectedPending: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{
+				getAppByNameBodies: []string{
+					`{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"status":"CREATING"}}`,
+					`{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"status":"CREATING"}}`,
+				},
+				getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"status":"RUNNING"}}`,
+			}
+
+			ctx := context.Background()
+			r := &AppResource{client: client, clock: &fakeClock{}}
+
+			state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+				"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+				"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+				"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+				"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+				"wait_for_ready":            tftypes.NewValue(tftypes.Bool, tc.waitForReady),
+			})
+
+			resp := fwresource.CreateResponse{State: state}
+			r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(state)}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			// without waiting, only the read at the end of the create consumes
+			// a CREATING response.
+			if pending := len(client.getAppByNameBodies); pending != tc.expectedPending {
+				t.Errorf("expected %d pending CREATING responses, got %d", tc.expectedPending, pending)
+			}
+
+			var data AppResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if tc.waitForReady && data.Status.ValueString() != "RUNNING" {
+				t.Errorf("expected status RUNNING, got %s", data.Status)
+			}
+		})
+	}
 }
-`, configurableAttribute)
+
+func TestAppResourceCreateCancelled(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"status":"CREATING"}}`,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the real clock is used, so the create blocks on the poll interval
+	// unless the cancellation interrupts it.
+	r := &AppResource{client: client}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"wait_for_ready":            tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	resp := fwresource.CreateResponse{State: state}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(state)}, &resp)
+
+	if elapsed := time.Since(start); elapsed >= appReadyPollInterval {
+		t.Errorf("expected the create to return promptly after the cancellation, took %s", elapsed)
+	}
+
+	cancelled := 0
+	for _, d := range resp.Diagnostics.Errors() {
+		switch d.Summary() {
+		case "Operation cancelled":
+			cancelled++
+		case "Operation timed out":
+			t.Errorf("expected a cancellation, not a timeout: %s", d.Detail())
+		}
+	}
+
+	if cancelled != 1 {
+		t.Errorf("expected one cancellation diagnostic, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestAppResourceCreateTimeout(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"status":"CREATING"}}`,
+	}
+
+	ctx := context.Background()
+
+	// the provider timeout is longer than the test, so only the create
+	// timeout can end the wait for the app to be ready.
+	r := &AppResource{client: client, timeout: time.Hour}
+
+	timeoutsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"create": tftypes.String,
+		"update": tftypes.String,
+		"delete": tftypes.String,
+	}}
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"wait_for_ready":            tftypes.NewValue(tftypes.Bool, true),
+		"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+			"create": tftypes.NewValue(tftypes.String, "50ms"),
+			"update": tftypes.NewValue(tftypes.String, nil),
+			"delete": tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+
+	start := time.Now()
+	resp := fwresource.CreateResponse{State: state}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(state)}, &resp)
+
+	if elapsed := time.Since(start); elapsed >= appReadyPollInterval {
+		t.Errorf("expected the create to time out after 50ms, took %s", elapsed)
+	}
+
+	var timedOut bool
+	for _, d := range resp.Diagnostics.Errors() {
+		if d.Summary() == "Operation timed out" && strings.Contains(d.Detail(), "50ms") {
+			timedOut = true
+		}
+	}
+
+	if !timedOut {
+		t.Errorf("expected the create to time out, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestAppResourceCreateAdoptExisting(t *testing.T) {
+	testCases := []struct {
+		name             string
+		adoptExisting    bool
+		statusCode       int
+		expectError      bool
+		expectPlanChange bool
+	}{
+		{name: "adopt on conflict", adoptExisting: true, statusCode: http.StatusConflict, expectPlanChange: true},
+		{name: "conflict without adopting", adoptExisting: false, statusCode: http.StatusConflict, expectError: true},
+		{name: "other errors aren't adopted", adoptExisting: true, statusCode: http.StatusBadRequest, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{
+				createAppStatusCode: tc.statusCode,
+				getAppByNameBody:    `{"project":{"_id":"existing","project_id":"my-app","type":"docker","planID":"small","scale":1,"status":"RUNNING"}}`,
+				getDisksBody:        `{"disks":[],"mounts":[]}`,
+				getAppDomainsBody:   `{"domains":[]}`,
+			}
+
+			ctx := context.Background()
+			r := &AppResource{client: client}
+
+			state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+				"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+				"plan_id":                   tftypes.NewValue(tftypes.String, "power"),
+				"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+				"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+				"adopt_existing":            tftypes.NewValue(tftypes.Bool, tc.adoptExisting),
+			})
+
+			resp := fwresource.CreateResponse{State: state}
+			r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(state)}, &resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Fatalf("expected error %t, got diagnostics: %v", tc.expectError, resp.Diagnostics)
+			}
+
+			if changed := len(client.changePlanBodies) > 0; changed != tc.expectPlanChange {
+				t.Errorf("expected plan change %t, got %d ChangePlan calls", tc.expectPlanChange, len(client.changePlanBodies))
+			}
+
+			if tc.expectError {
+				return
+			}
+
+			var data AppResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.ID.ValueString() != "existing" {
+				t.Errorf("expected the existing app to be adopted, got id %s", data.ID)
+			}
+		})
+	}
+}
+
+func TestAppResourceCreateAdoptExistingReconciles(t *testing.T) {
+	// the existing app was turned off, and already has a disk and a domain.
+	client := &fakePaasClient{
+		createAppStatusCode: http.StatusConflict,
+		getAppByNameBody:    `{"project":{"_id":"existing","project_id":"my-app","type":"docker","planID":"small","scale":0,"status":"RUNNING"}}`,
+		getDisksBody:        `{"disks":[{"name":"data","size":1}],"mounts":[{"name":"data","mountedTo":"/data"}]}`,
+		getAppDomainsBody:   `{"domains":[{"_id":"d1","name":"example.com","status":"OK","CNameRecord":"my-app.liara.run"}]}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	disksType := testAppResourceAttributeType(ctx, t, r, "disks")
+	diskType := disksType.(tftypes.List).ElementType
+
+	plan := tfsdk.Plan(testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"adopt_existing":            tftypes.NewValue(tftypes.Bool, true),
+		"turn_off":                  tftypes.NewValue(tftypes.Bool, false),
+		"scale":                     tftypes.NewValue(tftypes.Number, 1),
+		"disks": tftypes.NewValue(disksType, []tftypes.Value{
+			tftypes.NewValue(diskType, map[string]tftypes.Value{
+				"name":       tftypes.NewValue(tftypes.String, "data"),
+				"mount_path": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"size_gb":    tftypes.NewValue(tftypes.Number, 2),
+			}),
+		}),
+		"domains": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "example.com"),
+		}),
+		"domain_verifications": tftypes.NewValue(testAppResourceAttributeType(ctx, t, r, "domain_verifications"), tftypes.UnknownValue),
+	}))
+
+	resp := fwresource.CreateResponse{State: testAppResourceState(ctx, t, r, nil)}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(client.createDiskBodies) != 0 || len(client.deletedDisks) != 0 {
+		t.Errorf("expected the existing disk to be kept, got %d create and %d delete calls", len(client.createDiskBodies), len(client.deletedDisks))
+	}
+
+	if len(client.resizeDiskBodies) != 1 || client.resizeDiskBodies[0].Size != "2" {
+		t.Errorf("expected the existing disk to be resized to 2, got %+v", client.resizeDiskBodies)
+	}
+
+	if len(client.createAppDomainBodies) != 0 || len(client.deletedDomains) != 0 {
+		t.Errorf("expected the existing domain to be kept, got %d create and %d delete calls", len(client.createAppDomainBodies), len(client.deletedDomains))
+	}
+
+	if len(client.turnAppBodies) != 1 || client.turnAppBodies[0].Scale != 1 {
+		t.Errorf("expected the turned off app to be turned on with scale 1, got %+v", client.turnAppBodies)
+	}
+
+	if len(client.changePlanBodies) != 0 {
+		t.Errorf("expected the plan to be kept, got %d ChangePlan calls", len(client.changePlanBodies))
+	}
+}
+
+func TestAppResourceUpdateScale(t *testing.T) {
+	client := &fakePaasClient{}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	attributes := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"scale":                     tftypes.NewValue(tftypes.Number, 1),
+	}
+	state := testAppResourceState(ctx, t, r, attributes)
+
+	attributes["scale"] = tftypes.NewValue(tftypes.Number, 3)
+	plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(client.turnAppBodies) != 1 {
+		t.Fatalf("expected the scale endpoint to be called once, got %d calls", len(client.turnAppBodies))
+	}
+
+	if scale := client.turnAppBodies[0].Scale; scale != 3 {
+		t.Errorf("expected scale 3, got %v", scale)
+	}
+}
+
+func TestAppResourceUpdateZeroDowntime(t *testing.T) {
+	testCases := []struct {
+		name           string
+		state          tftypes.Value
+		plan           tftypes.Value
+		expectStatuses []string
+	}{
+		{
+			name:  "already enabled",
+			state: tftypes.NewValue(tftypes.Bool, true),
+			plan:  tftypes.NewValue(tftypes.Bool, true),
+		},
+		{
+			name:  "already disabled",
+			state: tftypes.NewValue(tftypes.Bool, nil),
+			plan:  tftypes.NewValue(tftypes.Bool, false),
+		},
+		{
+			name:           "disabling",
+			state:          tftypes.NewValue(tftypes.Bool, true),
+			plan:           tftypes.NewValue(tftypes.Bool, false),
+			expectStatuses: []string{"disable"},
+		},
+		{
+			name:           "enabling",
+			state:          tftypes.NewValue(tftypes.Bool, nil),
+			plan:           tftypes.NewValue(tftypes.Bool, true),
+			expectStatuses: []string{"enable"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{}
+
+			ctx := context.Background()
+			r := &AppResource{client: client}
+
+			attributes := map[string]tftypes.Value{
+				"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+				"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+				"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+				"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+				"zero_downtime":             tc.state,
+			}
+			state := testAppResourceState(ctx, t, r, attributes)
+
+			attributes["zero_downtime"] = tc.plan
+			plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+			resp := fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !reflect.DeepEqual(client.zeroDowntimeStatuses, tc.expectStatuses) {
+				t.Errorf("expected zero-downtime calls %v, got %v", tc.expectStatuses, client.zeroDowntimeStatuses)
+			}
+
+			if client.restartCount != 0 {
+				t.Errorf("expected no restart, got %d", client.restartCount)
+			}
+		})
+	}
+}
+
+func TestAppResourceUpdateStaticIPAndSubdomain(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		state                  bool
+		plan                   bool
+		expectIPStatic         []string
+		expectDefaultSubdomain []string
+	}{
+		{
+			name:  "no-op",
+			state: true,
+			plan:  true,
+		},
+		{
+			name:                   "disabling static ip and enabling default subdomain",
+			state:                  true,
+			plan:                   false,
+			expectIPStatic:         []string{"disable"},
+			expectDefaultSubdomain: []string{"enable"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{}
+
+			ctx := context.Background()
+			r := &AppResource{client: client}
+
+			attributes := map[string]tftypes.Value{
+				"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+				"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+				"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+				"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+				"enable_static_ip":          tftypes.NewValue(tftypes.Bool, tc.state),
+				"disable_default_subdomain": tftypes.NewValue(tftypes.Bool, tc.state),
+			}
+			state := testAppResourceState(ctx, t, r, attributes)
+
+			attributes["enable_static_ip"] = tftypes.NewValue(tftypes.Bool, tc.plan)
+			attributes["disable_default_subdomain"] = tftypes.NewValue(tftypes.Bool, tc.plan)
+			plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+			resp := fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !reflect.DeepEqual(client.ipStaticStatuses, tc.expectIPStatic) {
+				t.Errorf("expected static ip calls %v, got %v", tc.expectIPStatic, client.ipStaticStatuses)
+			}
+
+			if !reflect.DeepEqual(client.defaultSubdomainStatuses, tc.expectDefaultSubdomain) {
+				t.Errorf("expected default subdomain calls %v, got %v", tc.expectDefaultSubdomain, client.defaultSubdomainStatuses)
+			}
+		})
+	}
+}
+
+func TestAppResourceUpdateDefaultSubdomain(t *testing.T) {
+	testCases := []struct {
+		name          string
+		state         tftypes.Value
+		plan          tftypes.Value
+		expectUpdates []string
+	}{
+		{
+			name:          "disabling",
+			state:         tftypes.NewValue(tftypes.Bool, false),
+			plan:          tftypes.NewValue(tftypes.Bool, true),
+			expectUpdates: []string{"disable"},
+		},
+		{
+			name:          "re-enabling",
+			state:         tftypes.NewValue(tftypes.Bool, true),
+			plan:          tftypes.NewValue(tftypes.Bool, false),
+			expectUpdates: []string{"enable"},
+		},
+		{
+			name:  "unset",
+			state: tftypes.NewValue(tftypes.Bool, true),
+			plan:  tftypes.NewValue(tftypes.Bool, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{}
+
+			ctx := context.Background()
+			r := &AppResource{client: client}
+
+			attributes := map[string]tftypes.Value{
+				"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+				"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+				"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+				"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+				"disable_default_subdomain": tc.state,
+			}
+			state := testAppResourceState(ctx, t, r, attributes)
+
+			attributes["disable_default_subdomain"] = tc.plan
+			plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+			resp := fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !reflect.DeepEqual(client.defaultSubdomainStatuses, tc.expectUpdates) {
+				t.Errorf("expected default subdomain calls %v, got %v", tc.expectUpdates, client.defaultSubdomainStatuses)
+			}
+		})
+	}
+}
+
+func TestAppResourceUpdateDisableStaticIP(t *testing.T) {
+	client := &fakePaasClient{}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	attributes := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"enable_static_ip":          tftypes.NewValue(tftypes.Bool, true),
+		"static_ip":                 tftypes.NewValue(tftypes.String, "1.2.3.4"),
+	}
+	state := testAppResourceState(ctx, t, r, attributes)
+
+	// the static ip is planned as unknown when it is disabled.
+	attributes["enable_static_ip"] = tftypes.NewValue(tftypes.Bool, false)
+	attributes["static_ip"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+	resp := fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if expected := []string{"disable"}; !reflect.DeepEqual(client.ipStaticStatuses, expected) {
+		t.Errorf("expected static ip calls %v, got %v", expected, client.ipStaticStatuses)
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if !data.StaticIP.IsNull() {
+		t.Errorf("expected the static ip to be cleared, got %s", data.StaticIP)
+	}
+}
+
+func TestAppResourceCreateWaitsForStaticIP(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBodies: []string{
+			`{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"node":{"IP":""}}}`,
+			`{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"node":{"IP":""}}}`,
+		},
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"node":{"IP":"1.2.3.4"}}}`,
+	}
+
+	ctx := context.Background()
+	c := &fakeClock{}
+	r := &AppResource{client: client, clock: c}
+
+	plan := tfsdk.Plan(testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"enable_static_ip":          tftypes.NewValue(tftypes.Bool, true),
+		"static_ip":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"scale":                     tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+	}))
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if pending := len(client.getAppByNameBodies); pending != 0 {
+		t.Errorf("expected the app to be polled until the static ip is assigned, %d responses are pending", pending)
+	}
+
+	if waits := c.Waits(); !reflect.DeepEqual(waits, []time.Duration{appStaticIPPollInterval, 2 * appStaticIPPollInterval}) {
+		t.Errorf("expected the wait to double between the checks, got %v", waits)
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.StaticIP.ValueString() != "1.2.3.4" {
+		t.Errorf("expected static ip 1.2.3.4, got %s", data.StaticIP)
+	}
+}
+
+func TestAppResourceModifyPlanStaticIP(t *testing.T) {
+	ctx := context.Background()
+	r := &AppResource{}
+
+	attributes := map[string]tftypes.Value{
+		"name":             tftypes.NewValue(tftypes.String, "my-app"),
+		"enable_static_ip": tftypes.NewValue(tftypes.Bool, false),
+	}
+	state := testAppResourceState(ctx, t, r, attributes)
+
+	for enabled, expectUnknown := range map[bool]bool{false: false, true: true} {
+		attributes["enable_static_ip"] = tftypes.NewValue(tftypes.Bool, enabled)
+		plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+		config := tfsdk.Config(testAppResourceState(ctx, t, r, attributes))
+
+		resp := fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Config: config, Plan: plan, State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var staticIP types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("static_ip"), &staticIP)...)
+		if staticIP.IsUnknown() != expectUnknown {
+			t.Errorf("enable_static_ip %t: expected static_ip unknown %t, got %s", enabled, expectUnknown, staticIP)
+		}
+	}
+}
+
+func TestAppResourceModifyPlanScale(t *testing.T) {
+	ctx := context.Background()
+	r := &AppResource{}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "my-app"),
+		"turn_off": tftypes.NewValue(tftypes.Bool, true),
+		"scale":    tftypes.NewValue(tftypes.Number, 2),
+	})
+
+	testCases := []struct {
+		name            string
+		turnOff         tftypes.Value
+		configuredScale tftypes.Value
+		expectUnknown   bool
+	}{
+		{
+			name:            "turned on",
+			turnOff:         tftypes.NewValue(tftypes.Bool, false),
+			configuredScale: tftypes.NewValue(tftypes.Number, nil),
+			expectUnknown:   true,
+		},
+		{
+			name:            "turned on with a configured scale",
+			turnOff:         tftypes.NewValue(tftypes.Bool, false),
+			configuredScale: tftypes.NewValue(tftypes.Number, 3),
+		},
+		{
+			name:            "kept turned off",
+			turnOff:         tftypes.NewValue(tftypes.Bool, true),
+			configuredScale: tftypes.NewValue(tftypes.Number, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plannedScale := tc.configuredScale
+			if plannedScale.IsNull() {
+				plannedScale = tftypes.NewValue(tftypes.Number, 2)
+			}
+
+			plan := tfsdk.Plan(testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+				"name":     tftypes.NewValue(tftypes.String, "my-app"),
+				"turn_off": tc.turnOff,
+				"scale":    plannedScale,
+			}))
+			config := tfsdk.Config(testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+				"name":     tftypes.NewValue(tftypes.String, "my-app"),
+				"turn_off": tc.turnOff,
+				"scale":    tc.configuredScale,
+			}))
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Config: config, Plan: plan, State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var scale types.Int64
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("scale"), &scale)...)
+			if scale.IsUnknown() != tc.expectUnknown {
+				t.Errorf("expected scale unknown %t, got %s", tc.expectUnknown, scale)
+			}
+		})
+	}
+}
+
+func TestAppResourcePlanStaticIP(t *testing.T) {
+	ctx := context.Background()
+	r := &AppResource{}
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objectType := testAppResourceState(ctx, t, r, nil).Raw.Type()
+	dynamicValue := func(attributes map[string]tftypes.Value) *tfprotov6.DynamicValue {
+		value, err := tfprotov6.NewDynamicValue(objectType, testObjectValue(objectType, attributes))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &value
+	}
+
+	attributes := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"wait_for_ready":            tftypes.NewValue(tftypes.Bool, true),
+		"static_ip":                 tftypes.NewValue(tftypes.String, "1.2.3.4"),
+	}
+
+	validateResp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: "liara_app",
+		Config:   dynamicValue(attributes),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(validateResp.Diagnostics) != 1 || validateResp.Diagnostics[0].Summary != "Invalid Configuration for Read-Only Attribute" {
+		t.Errorf("expected static_ip to be rejected in the configuration, got: %v", validateResp.Diagnostics)
+	}
+
+	// enabling the static ip plans an unknown static_ip, which is populated
+	// on apply.
+	attributes["static_ip"] = tftypes.NewValue(tftypes.String, nil)
+	priorState := dynamicValue(attributes)
+
+	attributes["enable_static_ip"] = tftypes.NewValue(tftypes.Bool, true)
+	planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "liara_app",
+		PriorState:       priorState,
+		ProposedNewState: dynamicValue(attributes),
+		Config:           dynamicValue(attributes),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(planResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", planResp.Diagnostics)
+	}
+
+	planned, err := planResp.PlannedState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var plannedAttributes map[string]tftypes.Value
+	if err := planned.As(&plannedAttributes); err != nil {
+		t.Fatal(err)
+	}
+
+	if plannedAttributes["static_ip"].IsKnown() {
+		t.Errorf("expected static_ip to be unknown in the plan, got %s", plannedAttributes["static_ip"])
+	}
+}
+
+func TestAppResourceCreateSideCalls(t *testing.T) {
+	testCases := []struct {
+		name           string
+		failingMethods []string
+	}{
+		{name: "all succeed"},
+		{name: "several fail", failingMethods: []string{"UpdateEnvs", "IpStatic", "DefaultSubdomain"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{
+				getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"isDeployed":true,"node":{"IP":"1.2.3.4"}}}`,
+				failingMethods:   tc.failingMethods,
+			}
+
+			ctx := context.Background()
+			r := &AppResource{client: client}
+
+			plan := tfsdk.Plan(testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+				"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+				"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+				"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+				"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+				"scale":                     tftypes.NewValue(tftypes.Number, 2),
+				"zero_downtime":             tftypes.NewValue(tftypes.Bool, true),
+				"envs": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"PORT": tftypes.NewValue(tftypes.String, "8080"),
+				}),
+				"enable_static_ip":          tftypes.NewValue(tftypes.Bool, true),
+				"disable_default_subdomain": tftypes.NewValue(tftypes.Bool, true),
+				"static_ip":                 tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}))
+
+			resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+
+			if len(client.turnAppBodies) != 1 || len(client.zeroDowntimeStatuses) != 1 || len(client.updateEnvsBodies) != 1 ||
+				len(client.ipStaticStatuses) != 1 || len(client.defaultSubdomainStatuses) != 1 {
+				t.Errorf("expected every side call once, got scale %d, zero-downtime %d, envs %d, static ip %d and default subdomain %d calls",
+					len(client.turnAppBodies), len(client.zeroDowntimeStatuses), len(client.updateEnvsBodies),
+					len(client.ipStaticStatuses), len(client.defaultSubdomainStatuses))
+			}
+
+			if len(tc.failingMethods) == 0 {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.ErrorsCount() != len(tc.failingMethods) {
+				t.Errorf("expected %d errors, got: %v", len(tc.failingMethods), resp.Diagnostics)
+			}
+
+			for _, method := range tc.failingMethods {
+				found := false
+				for _, diagnostic := range resp.Diagnostics.Errors() {
+					if strings.Contains(diagnostic.Detail(), method+" failed") {
+						found = true
+					}
+				}
+
+				if !found {
+					t.Errorf("expected the %s error to be reported, got: %v", method, resp.Diagnostics)
+				}
+			}
+		})
+	}
+}
+
+func TestAppResourceCreateRedeploysInOrder(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody:  `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1}}`,
+		getDisksBody:      `{"disks":[{"name":"data","size":1}],"mounts":[{"name":"data","mountedTo":"/data"}]}`,
+		getAppDomainsBody: `{"domains":[]}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	disksType := testAppResourceAttributeType(ctx, t, r, "disks")
+	diskType := disksType.(tftypes.List).ElementType
+
+	plan := tfsdk.Plan(testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"turn_off":                  tftypes.NewValue(tftypes.Bool, true),
+		"envs": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"PORT": tftypes.NewValue(tftypes.String, "8080"),
+		}),
+		"disks": tftypes.NewValue(disksType, []tftypes.Value{
+			tftypes.NewValue(diskType, map[string]tftypes.Value{
+				"name":       tftypes.NewValue(tftypes.String, "data"),
+				"mount_path": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"size_gb":    tftypes.NewValue(tftypes.Number, 1),
+			}),
+		}),
+	}))
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	// the envs and disks redeploy the app, which is turned off last.
+	if redeploys := strings.Join(client.redeploys, ","); redeploys != "UpdateEnvs,CreateDisk,TurnApp" {
+		t.Errorf("expected the app to be redeployed by the envs, the disk and the turn off in order, got %s", redeploys)
+	}
+}
+
+func TestAppResourceCreateZeroDowntimeAlreadySet(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1,"zeroDowntime":true}}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	plan := tfsdk.Plan(testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"zero_downtime":             tftypes.NewValue(tftypes.Bool, true),
+		"scale":                     tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+	}))
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(client.zeroDowntimeStatuses) != 0 {
+		t.Errorf("expected no zero-downtime calls, got %v", client.zeroDowntimeStatuses)
+	}
+}
+
+func TestAppResourceUpdateRestartTrigger(t *testing.T) {
+	testCases := []struct {
+		name          string
+		prior         tftypes.Value
+		planned       tftypes.Value
+		expectRestart bool
+	}{
+		{name: "unchanged", prior: tftypes.NewValue(tftypes.String, "1"), planned: tftypes.NewValue(tftypes.String, "1")},
+		{name: "changed", prior: tftypes.NewValue(tftypes.String, "1"), planned: tftypes.NewValue(tftypes.String, "2"), expectRestart: true},
+		{name: "set", prior: tftypes.NewValue(tftypes.String, nil), planned: tftypes.NewValue(tftypes.String, "1"), expectRestart: true},
+		{name: "unset", prior: tftypes.NewValue(tftypes.String, "1"), planned: tftypes.NewValue(tftypes.String, nil)},
+		{name: "null", prior: tftypes.NewValue(tftypes.String, nil), planned: tftypes.NewValue(tftypes.String, nil)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakePaasClient{}
+
+			ctx := context.Background()
+			r := &AppResource{client: client}
+
+			attributes := map[string]tftypes.Value{
+				"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+				"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+				"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+				"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+				"restart_trigger":           tc.prior,
+			}
+			state := testAppResourceState(ctx, t, r, attributes)
+
+			attributes["restart_trigger"] = tc.planned
+			plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+			resp := fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if restarted := client.restartCount > 0; restarted != tc.expectRestart {
+				t.Errorf("expected restart %t, got %d restarts", tc.expectRestart, client.restartCount)
+			}
+		})
+	}
+}
+
+func TestAppResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &AppResource{}
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatal("expected a state upgrader from version 0")
+	}
+
+	req := fwresource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"id":"id","name":"my-app","rolling_update":true}`)},
+	}
+	resp := fwresource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	upgraded, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data AppResourceModel
+	resp.Diagnostics.Append(tfsdk.State{Schema: schemaResp.Schema, Raw: upgraded}.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if !data.ZeroDowntime.ValueBool() || data.Name.ValueString() != "my-app" {
+		t.Errorf("expected rolling_update to be moved to zero_downtime, got %v", data)
+	}
+}
+
+func TestAppResourceScaleValidation(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := fwresource.SchemaResponse{}
+	(&AppResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	attribute, ok := schemaResp.Schema.Attributes["scale"].(schema.Int64Attribute)
+	if !ok {
+		t.Fatalf("unexpected scale attribute type %T", schemaResp.Schema.Attributes["scale"])
+	}
+
+	for value, expectError := range map[int64]bool{-1: true, 0: true, 1: false, 3: false} {
+		req := validator.Int64Request{
+			Path:        path.Root("scale"),
+			ConfigValue: types.Int64Value(value),
+		}
+		resp := validator.Int64Response{}
+
+		for _, v := range attribute.Validators {
+			v.ValidateInt64(ctx, req, &resp)
+		}
+
+		if resp.Diagnostics.HasError() != expectError {
+			t.Errorf("scale %d: expected error %t, got diagnostics: %v", value, expectError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestAppResourceEnvKeysValidation(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := fwresource.SchemaResponse{}
+	(&AppResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	testCases := []struct {
+		name         string
+		keys         []string
+		expectErrors []string
+	}{
+		{name: "valid", keys: []string{"PORT", "_private", "db_host_2"}},
+		{name: "space", keys: []string{"PORT", "API KEY"}, expectErrors: []string{"API KEY"}},
+		{name: "leading digit and dash", keys: []string{"1PORT", "DB-HOST"}, expectErrors: []string{"1PORT", "DB-HOST"}},
+	}
+
+	for _, name := range []string{"envs", "secret_envs"} {
+		attribute, ok := schemaResp.Schema.Attributes[name].(schema.MapAttribute)
+		if !ok {
+			t.Fatalf("unexpected %s attribute type %T", name, schemaResp.Schema.Attributes[name])
+		}
+
+		for _, tc := range testCases {
+			t.Run(name+" "+tc.name, func(t *testing.T) {
+				envs := make(map[string]attr.Value)
+				for _, key := range tc.keys {
+					envs[key] = types.StringValue("value")
+				}
+
+				req := validator.MapRequest{
+					Path:        path.Root(name),
+					ConfigValue: types.MapValueMust(types.StringType, envs),
+				}
+				resp := validator.MapResponse{}
+
+				for _, v := range attribute.Validators {
+					v.ValidateMap(ctx, req, &resp)
+				}
+
+				if resp.Diagnostics.ErrorsCount() != len(tc.expectErrors) {
+					t.Fatalf("expected %d errors, got: %v", len(tc.expectErrors), resp.Diagnostics)
+				}
+
+				for _, key := range tc.expectErrors {
+					expectedPath := path.Root(name).AtMapKey(key)
+
+					found := false
+					for _, diagnostic := range resp.Diagnostics.Errors() {
+						if withPath, ok := diagnostic.(diag.DiagnosticWithPath); ok && withPath.Path().Equal(expectedPath) {
+							found = true
+						}
+					}
+
+					if !found {
+						t.Errorf("expected an error on %s, got: %v", expectedPath, resp.Diagnostics)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestAppResourcePlatformRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+	r := &AppResource{}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	attribute, ok := schemaResp.Schema.Attributes["platform"].(schema.StringAttribute)
+	if !ok {
+		t.Fatalf("unexpected platform attribute type %T", schemaResp.Schema.Attributes["platform"])
+	}
+
+	testCases := []struct {
+		name           string
+		platform       string
+		requireReplace bool
+	}{
+		{name: "unchanged", platform: "docker", requireReplace: false},
+		{name: "changed", platform: "node", requireReplace: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes := map[string]tftypes.Value{
+				"name":     tftypes.NewValue(tftypes.String, "my-app"),
+				"platform": tftypes.NewValue(tftypes.String, "docker"),
+			}
+			state := testAppResourceState(ctx, t, r, attributes)
+
+			attributes["platform"] = tftypes.NewValue(tftypes.String, tc.platform)
+			plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("platform"),
+				State:       state,
+				StateValue:  types.StringValue("docker"),
+				Plan:        plan,
+				PlanValue:   types.StringValue(tc.platform),
+				ConfigValue: types.StringValue(tc.platform),
+			}
+			resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			for _, m := range attribute.PlanModifiers {
+				m.PlanModifyString(ctx, req, &resp)
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if resp.RequiresReplace != tc.requireReplace {
+				t.Errorf("expected requires replace %t, got %t", tc.requireReplace, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestAppResourcePlatformValidation(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := fwresource.SchemaResponse{}
+	(&AppResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	attribute, ok := schemaResp.Schema.Attributes["platform"].(schema.StringAttribute)
+	if !ok {
+		t.Fatalf("unexpected platform attribute type %T", schemaResp.Schema.Attributes["platform"])
+	}
+
+	for value, expectError := range map[string]bool{"nodjs": true, "node": false, "docker": false} {
+		req := validator.StringRequest{
+			Path:        path.Root("platform"),
+			ConfigValue: types.StringValue(value),
+		}
+		resp := validator.StringResponse{}
+
+		for _, v := range attribute.Validators {
+			v.ValidateString(ctx, req, &resp)
+		}
+
+		if resp.Diagnostics.HasError() != expectError {
+			t.Fatalf("platform %s: expected error %t, got diagnostics: %v", value, expectError, resp.Diagnostics)
+		}
+
+		if !expectError {
+			continue
+		}
+
+		diagnostic := resp.Diagnostics.Errors()[0]
+		if !strings.Contains(diagnostic.Detail(), value) || !strings.Contains(diagnostic.Detail(), "docker") {
+			t.Errorf("expected the diagnostic to name the bad value and the allowed set, got: %s", diagnostic.Detail())
+		}
+	}
+}
+
+func TestAppResourceDisks(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1}}`,
+		getDisksBody:     `{"disks":[{"name":"data","size":1}],"mounts":[{"name":"data","mountedTo":"/data"}]}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	disksType := testAppResourceAttributeType(ctx, t, r, "disks")
+	diskType := disksType.(tftypes.List).ElementType
+	disks := func(size int) tftypes.Value {
+		return tftypes.NewValue(disksType, []tftypes.Value{
+			tftypes.NewValue(diskType, map[string]tftypes.Value{
+				"name":       tftypes.NewValue(tftypes.String, "data"),
+				"mount_path": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"size_gb":    tftypes.NewValue(tftypes.Number, size),
+			}),
+		})
+	}
+
+	attributes := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"scale":                     tftypes.NewValue(tftypes.Number, 1),
+		"disks":                     disks(1),
+	}
+
+	// create the app with one disk
+	plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+	createResp := fwresource.CreateResponse{State: testAppResourceState(ctx, t, r, nil)}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if len(client.createDiskBodies) != 1 {
+		t.Fatalf("expected one disk to be created, got %d", len(client.createDiskBodies))
+	}
+
+	if body := client.createDiskBodies[0]; body.Name != "data" || body.Size != "1" {
+		t.Errorf("unexpected create disk body: %+v", body)
+	}
+
+	var data AppResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &data)...)
+
+	var created []AppDiskModel
+	createResp.Diagnostics.Append(data.Disks.ElementsAs(ctx, &created, false)...)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if len(created) != 1 || created[0].MountPath.ValueString() != "/data" {
+		t.Errorf("expected the disk mount path to be read back, got %+v", created)
+	}
+
+	// grow the disk
+	attributes["disks"] = disks(2)
+	plan = tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+	updateResp := fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if len(client.createDiskBodies) != 1 {
+		t.Errorf("expected the disk not to be recreated, got %d create calls", len(client.createDiskBodies))
+	}
+
+	if len(client.deletedDisks) != 0 {
+		t.Errorf("expected no disk to be deleted, got %v", client.deletedDisks)
+	}
+
+	if len(client.resizeDiskBodies) != 1 || client.resizeDiskBodies[0].Size != "2" {
+		t.Errorf("expected the disk to be resized to 2, got %+v", client.resizeDiskBodies)
+	}
+}
+
+func TestAppResourceDomains(t *testing.T) {
+	client := &fakePaasClient{
+		getAppByNameBody: `{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1}}`,
+		getAppDomainsBody: `{"domains":[
+			{"_id":"d1","name":"example.com","status":"PENDING","CNameRecord":"my-app.liara.run"},
+			{"_id":"d2","name":"www.example.com","status":"OK","CNameRecord":"my-app.liara.run"}
+		]}`,
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	domains := func(names ...string) tftypes.Value {
+		values := make([]tftypes.Value, 0, len(names))
+		for _, name := range names {
+			values = append(values, tftypes.NewValue(tftypes.String, name))
+		}
+
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+	}
+
+	attributes := map[string]tftypes.Value{
+		"name":                      tftypes.NewValue(tftypes.String, "my-app"),
+		"plan_id":                   tftypes.NewValue(tftypes.String, "small"),
+		"platform":                  tftypes.NewValue(tftypes.String, "docker"),
+		"read_only_root_filesystem": tftypes.NewValue(tftypes.Bool, false),
+		"scale":                     tftypes.NewValue(tftypes.Number, 1),
+		"domains":                   domains("example.com", "www.example.com"),
+		"domain_verifications":      tftypes.NewValue(testAppResourceAttributeType(ctx, t, r, "domain_verifications"), tftypes.UnknownValue),
+	}
+
+	// the api doesn't know any domain before the app is created
+	getAppDomainsBody := client.getAppDomainsBody
+	client.getAppDomainsBody = `{"domains":[]}`
+
+	plan := tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+	createResp := fwresource.CreateResponse{State: testAppResourceState(ctx, t, r, nil)}
+	client.onCreateAppDomain = func() { client.getAppDomainsBody = getAppDomainsBody }
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if len(client.createAppDomainBodies) != 2 {
+		t.Fatalf("expected two domains to be added, got %d", len(client.createAppDomainBodies))
+	}
+
+	for _, body := range client.createAppDomainBodies {
+		if body.Project != "my-app" {
+			t.Errorf("expected the domain to be added to my-app, got %s", body.Project)
+		}
+	}
+
+	var data AppResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &data)...)
+
+	verifications := make(map[string]AppDomainVerificationModel)
+	createResp.Diagnostics.Append(data.DomainVerifications.ElementsAs(ctx, &verifications, false)...)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	verification := verifications["example.com"]
+	if verification.Status.ValueString() != "PENDING" {
+		t.Errorf("expected example.com to be pending, got %s", verification.Status)
+	}
+
+	if len(verification.DNSRecords) != 1 || verification.DNSRecords[0].Value.ValueString() != "my-app.liara.run" {
+		t.Errorf("expected a CNAME record to my-app.liara.run, got %+v", verification.DNSRecords)
+	}
+
+	// remove one of the domains
+	attributes["domains"] = domains("example.com")
+	plan = tfsdk.Plan(testAppResourceState(ctx, t, r, attributes))
+
+	updateResp := fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if len(client.createAppDomainBodies) != 2 {
+		t.Errorf("expected no domain to be added, got %d add calls", len(client.createAppDomainBodies))
+	}
+
+	if len(client.deletedDomains) != 1 || client.deletedDomains[0] != "d2" {
+		t.Errorf("expected domain d2 to be removed, got %v", client.deletedDomains)
+	}
+}
+
+// fakePaasClient is a paas.ClientInterface which records the request bodies
+// it receives. Calling a method which isn't overridden panics.
+type fakePaasClient struct {
+	paas.ClientInterface
+
+	// mu guards the fields, as the app resource calls some methods
+	// concurrently.
+	mu sync.Mutex
+
+	// failingMethods lists the methods which respond with an error.
+	failingMethods []string
+
+	getAppByNameBody string
+	// getAppByNameBodies are returned in order before getAppByNameBody.
+	getAppByNameBodies []string
+	getDisksBody       string
+
+	getAppDomainsBody string
+	onCreateAppDomain func()
+
+	// createAppStatusCode is the status of CreateAppWithBody (default: 200).
+	createAppStatusCode int
+
+	createAppBodies  []map[string]any
+	changePlanBodies []paas.ChangePlanJSONRequestBody
+	turnAppBodies    []paas.TurnAppJSONRequestBody
+	createDiskBodies []paas.CreateDiskJSONRequestBody
+	resizeDiskBodies []paas.ResizeDiskJSONRequestBody
+	deletedDisks     []string
+
+	createAppDomainBodies []paas.CreateAppDomainJSONRequestBody
+	deletedDomains        []string
+
+	updateEnvsBodies []paas.UpdateEnvsJSONRequestBody
+
+	// redeploys lists the calls which redeploy the app, in order.
+	redeploys []string
+
+	zeroDowntimeStatuses     []string
+	restartCount             int
+	ipStaticStatuses         []string
+	defaultSubdomainStatuses []string
+	deletedApps              []string
+}
+
+func (c *fakePaasClient) CreateAppWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var decoded map[string]any
+	if err := json.NewDecoder(body).Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	c.createAppBodies = append(c.createAppBodies, decoded)
+
+	if c.createAppStatusCode != 0 {
+		return testResponse(c.createAppStatusCode, `{"message":"Project already exists."}`), nil
+	}
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) UpdateEnvs(ctx context.Context, body paas.UpdateEnvsJSONRequestBody, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.updateEnvsBodies = append(c.updateEnvsBodies, body)
+	c.redeploys = append(c.redeploys, "UpdateEnvs")
+
+	if slices.Contains(c.failingMethods, "UpdateEnvs") {
+		return testResponse(http.StatusInternalServerError, `{"message":"UpdateEnvs failed"}`), nil
+	}
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) GetAppByName(ctx context.Context, name string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.getAppByNameBodies) > 0 {
+		body := c.getAppByNameBodies[0]
+		c.getAppByNameBodies = c.getAppByNameBodies[1:]
+
+		return testResponse(http.StatusOK, body), nil
+	}
+
+	return testResponse(http.StatusOK, c.getAppByNameBody), nil
+}
+
+func (c *fakePaasClient) ChangePlan(ctx context.Context, name string, body paas.ChangePlanJSONRequestBody, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.changePlanBodies = append(c.changePlanBodies, body)
+
+	if slices.Contains(c.failingMethods, "ChangePlan") {
+		return testResponse(http.StatusInternalServerError, `{"message":"ChangePlan failed"}`), nil
+	}
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) TurnApp(ctx context.Context, name string, body paas.TurnAppJSONRequestBody, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.turnAppBodies = append(c.turnAppBodies, body)
+	c.redeploys = append(c.redeploys, "TurnApp")
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) ZeroDowntime(ctx context.Context, id string, status string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.zeroDowntimeStatuses = append(c.zeroDowntimeStatuses, status)
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) IpStatic(ctx context.Context, name string, status string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ipStaticStatuses = append(c.ipStaticStatuses, status)
+
+	if slices.Contains(c.failingMethods, "IpStatic") {
+		return testResponse(http.StatusInternalServerError, `{"message":"IpStatic failed"}`), nil
+	}
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) DefaultSubdomain(ctx context.Context, name string, status string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.defaultSubdomainStatuses = append(c.defaultSubdomainStatuses, status)
+
+	if slices.Contains(c.failingMethods, "DefaultSubdomain") {
+		return testResponse(http.StatusInternalServerError, `{"message":"DefaultSubdomain failed"}`), nil
+	}
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) RestartApp(ctx context.Context, name string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.restartCount++
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) GetDisks(ctx context.Context, id string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return testResponse(http.StatusOK, c.getDisksBody), nil
+}
+
+func (c *fakePaasClient) CreateDisk(ctx context.Context, name string, body paas.CreateDiskJSONRequestBody, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.createDiskBodies = append(c.createDiskBodies, body)
+	c.redeploys = append(c.redeploys, "CreateDisk")
+
+	return testResponse(http.StatusCreated, `{}`), nil
+}
+
+func (c *fakePaasClient) ResizeDisk(ctx context.Context, name string, dname string, body paas.ResizeDiskJSONRequestBody, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resizeDiskBodies = append(c.resizeDiskBodies, body)
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) DeleteDisk(ctx context.Context, id string, name string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deletedDisks = append(c.deletedDisks, name)
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) DeleteAppByName(ctx context.Context, name string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deletedApps = append(c.deletedApps, name)
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func (c *fakePaasClient) GetAppDomains(ctx context.Context, params *paas.GetAppDomainsParams, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return testResponse(http.StatusOK, c.getAppDomainsBody), nil
+}
+
+func (c *fakePaasClient) CreateAppDomain(ctx context.Context, body paas.CreateAppDomainJSONRequestBody, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.createAppDomainBodies = append(c.createAppDomainBodies, body)
+
+	if c.onCreateAppDomain != nil {
+		c.onCreateAppDomain()
+	}
+
+	return testResponse(http.StatusCreated, `{"domain":{"_id":"new"}}`), nil
+}
+
+func (c *fakePaasClient) DeleteDomain(ctx context.Context, id string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deletedDomains = append(c.deletedDomains, id)
+
+	return testResponse(http.StatusOK, `{}`), nil
+}
+
+func testResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// testAppResourceState returns an app resource state holding the given
+// attribute values, with all the other attributes set to null.
+func testAppResourceState(ctx context.Context, t *testing.T, r *AppResource, attributes map[string]tftypes.Value) tfsdk.State {
+	t.Helper()
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	return tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), attributes),
+	}
+}
+
+// testAppResourceAttributeType returns the terraform type of the given app
+// resource attribute.
+func testAppResourceAttributeType(ctx context.Context, t *testing.T, r *AppResource, name string) tftypes.Type {
+	t.Helper()
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatal("expected the schema type to be an object")
+	}
+
+	return objectType.AttributeTypes[name]
 }
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &AppURLFunction{}
+
+func NewAppURLFunction() function.Function {
+	return &AppURLFunction{}
+}
+
+// AppURLFunction defines the function implementation.
+type AppURLFunction struct{}
+
+func (f *AppURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "app_url"
+}
+
+func (f *AppURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute the URL of an app",
+		MarkdownDescription: "Returns the URL of an app on the default subdomain of its region, e.g. `https://my-app.liara.run`. " +
+			"When a custom domain is passed, the URL of the custom domain is returned instead.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "app name",
+			},
+			function.StringParameter{
+				Name:                "region",
+				MarkdownDescription: fmt.Sprintf("Liara region of the app, one of %s", strings.Join(liaraRegionNames, ", ")),
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "custom_domain",
+			MarkdownDescription: "custom domain the app is served from, e.g. `www.example.com`",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *AppURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name, regionName string
+	var customDomain []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name, &regionName, &customDomain))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(name) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "name must not be empty")
+		return
+	}
+
+	region, ok := liaraRegions[regionName]
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("region must be one of %s, got: %s", strings.Join(liaraRegionNames, ", "), regionName))
+		return
+	}
+
+	if len(customDomain) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, "custom_domain can be passed at most once")
+		return
+	}
+
+	if len(customDomain) == 1 {
+		if len(customDomain[0]) == 0 {
+			resp.Error = function.NewArgumentFuncError(2, "custom_domain must not be empty")
+			return
+		}
+
+		resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, "https://"+customDomain[0]))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, fmt.Sprintf("https://%s.%s", name, region.AppDomain)))
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAppURLFunctionRun(t *testing.T) {
+	testCases := []struct {
+		name         string
+		region       string
+		customDomain []attr.Value
+		expected     string
+		expectError  bool
+	}{
+		{
+			name:     "iran region",
+			region:   "iran",
+			expected: "https://my-app.liara.run",
+		},
+		{
+			name:     "germany region",
+			region:   "germany",
+			expected: "https://my-app.liara.run",
+		},
+		{
+			name:        "unknown region",
+			region:      "mars",
+			expectError: true,
+		},
+		{
+			name:        "empty region",
+			region:      "",
+			expectError: true,
+		},
+		{
+			name:         "custom domain",
+			region:       "germany",
+			customDomain: []attr.Value{types.StringValue("www.example.com")},
+			expected:     "https://www.example.com",
+		},
+		{
+			name:         "empty custom domain",
+			region:       "iran",
+			customDomain: []attr.Value{types.StringValue("")},
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			elementTypes := make([]attr.Type, len(tc.customDomain))
+			for i := range elementTypes {
+				elementTypes[i] = types.StringType
+			}
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("my-app"),
+					types.StringValue(tc.region),
+					types.TupleValueMust(elementTypes, tc.customDomain),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			(&AppURLFunction{}).Run(ctx, req, &resp)
+			if tc.expectError {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			result, ok := resp.Result.Value().(types.String)
+			if !ok {
+				t.Fatalf("unexpected result type %T", resp.Result.Value())
+			}
+
+			if result.ValueString() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, result.ValueString())
+			}
+		})
+	}
+}
+
+func TestAppURLFunctionRunEveryRegion(t *testing.T) {
+	for _, name := range liaraRegionNames {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("my-app"),
+					types.StringValue(name),
+					types.TupleValueMust([]attr.Type{}, []attr.Value{}),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			(&AppURLFunction{}).Run(ctx, req, &resp)
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if expected := "https://my-app." + liaraRegions[name].AppDomain; !resp.Result.Value().Equal(types.StringValue(expected)) {
+				t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AppsDataSource{}
+
+func NewAppsDataSource() datasource.DataSource {
+	return &AppsDataSource{}
+}
+
+// AppsDataSource defines the data source implementation.
+type AppsDataSource struct {
+	client paas.ClientInterface
+}
+
+// AppsDataSourceModel describes the data source data model.
+type AppsDataSourceModel struct {
+	Platform types.String    `tfsdk:"platform"`
+	Status   types.String    `tfsdk:"status"`
+	Apps     []AppsItemModel `tfsdk:"apps"`
+}
+
+// AppsItemModel describes a single app of the listing.
+type AppsItemModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Platform types.String `tfsdk:"platform"`
+	PlanID   types.String `tfsdk:"plan_id"`
+	Status   types.String `tfsdk:"status"`
+}
+
+func (d *AppsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apps"
+}
+
+func (d *AppsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Apps data source, lists all the apps of the account, optionally filtered by platform and status",
+
+		Attributes: map[string]schema.Attribute{
+			"platform": schema.StringAttribute{
+				MarkdownDescription: "only list the apps of this platform",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(appPlatforms...),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "only list the apps with this status, e.g. RUNNING",
+				Optional:            true,
+			},
+			"apps": schema.ListNestedAttribute{
+				MarkdownDescription: "apps of the account matching the filters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "app id",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "app name",
+							Computed:            true,
+						},
+						"platform": schema.StringAttribute{
+							MarkdownDescription: "app platform",
+							Computed:            true,
+						},
+						"plan_id": schema.StringAttribute{
+							MarkdownDescription: "plan id",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "app status, e.g. RUNNING",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AppsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.PaasClient
+}
+
+func (d *AppsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apps := listApps(ctx, d.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Apps = make([]AppsItemModel, 0, len(apps))
+	for _, app := range apps {
+		if !data.Platform.IsNull() && app.Type != data.Platform.ValueString() {
+			continue
+		}
+
+		if !data.Status.IsNull() && app.Status != data.Status.ValueString() {
+			continue
+		}
+
+		data.Apps = append(data.Apps, AppsItemModel{
+			ID:       types.StringValue(app.ID),
+			Name:     types.StringValue(app.Name),
+			Platform: types.StringValue(app.Type),
+			PlanID:   types.StringValue(app.PlanID),
+			Status:   types.StringValue(app.Status),
+		})
+	}
+
+	tflog.Trace(ctx, "read apps data source", map[string]any{"apps": len(data.Apps)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+func TestAppsDataSourceReadPages(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"projects":[
+			{"_id":"1","project_id":"web","type":"docker","planID":"small","status":"RUNNING"},
+			{"_id":"2","project_id":"api","type":"node","planID":"medium","status":"RUNNING"}
+		]}`,
+		"2": `{"projects":[
+			{"_id":"3","project_id":"worker","type":"docker","planID":"small","status":"STOPPED"}
+		]}`,
+	}
+
+	var requestedPages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+
+		body, ok := pages[page]
+		if !ok {
+			body = `{"projects":[]}`
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	d := &AppsDataSource{client: client}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{}),
+		},
+	}
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	d.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if strings.Join(requestedPages, ",") != "1,2,3" {
+		t.Errorf("expected pages 1 to 3 to be requested, got %v", requestedPages)
+	}
+
+	var data AppsDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var names []string
+	for _, app := range data.Apps {
+		names = append(names, fmt.Sprintf("%s/%s/%s", app.Name.ValueString(), app.Platform.ValueString(), app.Status.ValueString()))
+	}
+
+	if expected := "web/docker/RUNNING,api/node/RUNNING,worker/docker/STOPPED"; strings.Join(names, ",") != expected {
+		t.Errorf("expected apps %s, got %v", expected, names)
+	}
+}
+
+func TestAppsDataSourceReadFiltered(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"projects":[
+			{"_id":"1","project_id":"web","type":"docker","planID":"small","status":"RUNNING"},
+			{"_id":"2","project_id":"api","type":"node","planID":"medium","status":"RUNNING"},
+			{"_id":"3","project_id":"worker","type":"docker","planID":"small","status":"STOPPED"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	d := &AppsDataSource{client: client}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	testCases := []struct {
+		name        string
+		filters     map[string]tftypes.Value
+		expectNames string
+	}{
+		{
+			name:        "unfiltered",
+			filters:     map[string]tftypes.Value{},
+			expectNames: "web,api,worker",
+		},
+		{
+			name: "platform",
+			filters: map[string]tftypes.Value{
+				"platform": tftypes.NewValue(tftypes.String, "docker"),
+			},
+			expectNames: "web,worker",
+		},
+		{
+			name: "platform and status",
+			filters: map[string]tftypes.Value{
+				"platform": tftypes.NewValue(tftypes.String, "docker"),
+				"status":   tftypes.NewValue(tftypes.String, "RUNNING"),
+			},
+			expectNames: "web",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw:    testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), tc.filters),
+				},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+
+			d.Read(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data AppsDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+
+			names := make([]string, 0, len(data.Apps))
+			for _, app := range data.Apps {
+				names = append(names, app.Name.ValueString())
+			}
+
+			if strings.Join(names, ",") != tc.expectNames {
+				t.Errorf("expected apps %s, got %v", tc.expectNames, names)
+			}
+		})
+	}
+}
+
+func TestListAppsUnpaginatedAPI(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		// the paging parameters are ignored, every page holds all the apps.
+		_, _ = w.Write([]byte(`{"projects":[{"_id":"1","project_id":"web"},{"_id":"2","project_id":"api"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := datasource.ReadResponse{}
+	apps := listApps(context.Background(), client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(apps) != 2 || requests != 2 {
+		t.Errorf("expected 2 apps in 2 requests, got %d apps in %d requests", len(apps), requests)
+	}
+}
+
+func TestListAppsSmallPages(t *testing.T) {
+	// the API pages the 25 apps by 10, which is fewer than a client could
+	// ask for.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+
+		var items []string
+		for i := (page - 1) * 10; i < page*10 && i < 25; i++ {
+			items = append(items, fmt.Sprintf(`{"_id":"%d","project_id":"app-%d"}`, i, i))
+		}
+
+		_, _ = fmt.Fprintf(w, `{"projects":[%s]}`, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	client, err := paas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := datasource.ReadResponse{}
+	apps := listApps(context.Background(), client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(apps) != 25 {
+		t.Errorf("expected 25 apps, got %d", len(apps))
+	}
+}
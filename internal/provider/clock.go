@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"time"
+)
+
+// clock abstracts the passing of time for the retry and polling loops, so
+// tests can run through them without waiting.
+type clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After sends the time on the returned channel once the duration has
+	// elapsed.
+	After(d time.Duration) <-chan time.Time
+
+	// Sleep waits for the duration to elapse, it returns the context's error
+	// if the context is done first.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// realClock is the clock of the system.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (c realClock) Sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.After(d):
+		return nil
+	}
+}
+
+// sleepContext waits for the duration on the clock, or on the system clock
+// when it is nil.
+func sleepContext(ctx context.Context, c clock, wait time.Duration) error {
+	if c == nil {
+		c = realClock{}
+	}
+
+	return c.Sleep(ctx, wait)
+}
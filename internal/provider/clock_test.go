@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock which advances instantly, recording the waits.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	c.waits = append(c.waits, d)
+
+	after := make(chan time.Time, 1)
+	after <- c.now
+
+	return after
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	<-c.After(d)
+
+	return nil
+}
+
+func (c *fakeClock) Waits() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]time.Duration(nil), c.waits...)
+}
+
+func TestRetryTransportFakeClock(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := &fakeClock{now: time.Now()}
+	client := &http.Client{Transport: newRetryTransport(nil, 4, 5*time.Second, 0, c)}
+
+	start := time.Now()
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	response.Body.Close()
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the retries not to wait in real time, took %s", elapsed)
+	}
+
+	if attempts != 5 {
+		t.Errorf("expected 5 attempts, got %d", attempts)
+	}
+
+	// the backoff doubles on each retry, with up to 50% jitter.
+	waits := c.Waits()
+	if len(waits) != 4 {
+		t.Fatalf("expected 4 waits, got %v", waits)
+	}
+
+	for i, wait := range waits {
+		base := 5 * time.Second << i
+		if wait < base || wait >= base+base/2 {
+			t.Errorf("retry #%d: expected a wait between %s and %s, got %s", i+1, base, base+base/2, wait)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/dbaas"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DBBackupResource{}
+
+func NewDBBackupResource() resource.Resource {
+	return &DBBackupResource{}
+}
+
+// DBBackupResource defines the resource implementation.
+type DBBackupResource struct {
+	client  dbaas.ClientInterface
+	timeout time.Duration
+}
+
+// DBBackupResourceModel describes the resource data model.
+type DBBackupResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	DatabaseID   types.String `tfsdk:"database_id"`
+	OnDemand     types.Bool   `tfsdk:"on_demand"`
+	LastBackupAt types.String `tfsdk:"last_backup_at"`
+}
+
+func (r *DBBackupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_db_backup"
+}
+
+func (r *DBBackupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Database backup resource, takes on-demand backups of a database. Backups are kept when the resource is destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database_id": schema.StringAttribute{
+				MarkdownDescription: "database id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"on_demand": schema.BoolAttribute{
+				MarkdownDescription: "take a backup on create, and whenever this changes to true",
+				Optional:            true,
+			},
+			"last_backup_at": schema.StringAttribute{
+				MarkdownDescription: "time of the latest backup of the database (null if it has none)",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *DBBackupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.DBaaSClient
+	r.timeout = providerData.Timeout
+}
+
+func (r *DBBackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data DBBackupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.OnDemand.ValueBool() {
+		r.createBackup(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if found := r.readLastBackup(ctx, &data, &resp.Diagnostics); !found {
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.AddError("Database not found", fmt.Sprintf("Database %q does not exist", data.DatabaseID.ValueString()))
+		}
+
+		return
+	}
+
+	data.ID = data.DatabaseID
+
+	tflog.Trace(ctx, "created a db backup resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DBBackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data DBBackupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if found := r.readLastBackup(ctx, &data, &resp.Diagnostics); !found {
+		// the database was removed outside of terraform.
+		if !resp.Diagnostics.HasError() {
+			resp.State.RemoveResource(ctx)
+		}
+
+		return
+	}
+
+	tflog.Trace(ctx, "read db backup resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DBBackupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data, state DBBackupResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.OnDemand.ValueBool() && !state.OnDemand.ValueBool() {
+		r.createBackup(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if found := r.readLastBackup(ctx, &data, &resp.Diagnostics); !found {
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.AddError("Database not found", fmt.Sprintf("Database %q does not exist", data.DatabaseID.ValueString()))
+		}
+
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DBBackupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// backups are kept when the resource is destroyed, so there is nothing
+	// to delete.
+	tflog.Trace(ctx, "deleted db backup resource")
+}
+
+func (r *DBBackupResource) createBackup(ctx context.Context, data *DBBackupResourceModel, diagnostics *diag.Diagnostics) {
+	response, err := r.client.CreateBackup(ctx, data.DatabaseID.ValueString())
+	if err != nil {
+		diagnostics.AddError("Creating backup failed", fmt.Sprintf("Unable to create backup, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading backup response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Creating backup failed", fmt.Sprintf("Unable to create backup, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	tflog.Trace(ctx, "created a database backup")
+}
+
+// readLastBackup sets the time of the latest backup, it returns false if the
+// database doesn't exist.
+func (r *DBBackupResource) readLastBackup(ctx context.Context, data *DBBackupResourceModel, diagnostics *diag.Diagnostics) bool {
+	response, err := r.client.GetListBackups(ctx, data.DatabaseID.ValueString())
+	if err != nil {
+		diagnostics.AddError("Reading backups failed", fmt.Sprintf("Unable to read backups, got error: %s", err))
+		return false
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode == http.StatusNotFound {
+		return false
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading backups response payload failed", err.Error())
+
+			return false
+		}
+
+		diagnostics.AddError("Reading backups failed", fmt.Sprintf("Unable to read backups, got error: %s", responseErrorMessage(response, body)))
+		return false
+	}
+
+	responseModel := struct {
+		Backups []struct {
+			Name         string `json:"name"`
+			LastModified string `json:"lastModified"`
+		} `json:"backups"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		diagnostics.AddError("Decoding backups response failed", fmt.Sprintf("Unable to decode backups response, got error: %s", err))
+		return false
+	}
+
+	data.LastBackupAt = types.StringNull()
+
+	var latest time.Time
+	for _, backup := range responseModel.Backups {
+		lastModified, err := time.Parse(time.RFC3339, backup.LastModified)
+		if err != nil {
+			continue
+		}
+
+		if data.LastBackupAt.IsNull() || lastModified.After(latest) {
+			latest = lastModified
+			data.LastBackupAt = types.StringValue(backup.LastModified)
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/dbaas"
+)
+
+func TestDBBackupResource(t *testing.T) {
+	type backup struct {
+		Name         string `json:"name"`
+		LastModified string `json:"lastModified"`
+	}
+
+	backups := []backup{
+		{Name: "b1", LastModified: "2024-01-01T10:00:00Z"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/databases/db1/backups" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			backups = append(backups, backup{
+				Name:         "b2",
+				LastModified: time.Date(2024, 1, len(backups)+1, 10, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"backups": backups})
+	}))
+	defer server.Close()
+
+	client, err := dbaas.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &DBBackupResource{client: client}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	plan := func(databaseID string, onDemand bool) tfsdk.Plan {
+		return tfsdk.Plan{
+			Schema: schemaResp.Schema,
+			Raw: testObjectValue(objectType, map[string]tftypes.Value{
+				"database_id":    tftypes.NewValue(tftypes.String, databaseID),
+				"on_demand":      tftypes.NewValue(tftypes.Bool, onDemand),
+				"last_backup_at": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"id":             tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+		}
+	}
+
+	emptyState := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+
+	createResp := fwresource.CreateResponse{State: emptyState}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan("db1", true)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if len(backups) != 2 {
+		t.Fatalf("expected an on-demand backup to be taken, got %d backups", len(backups))
+	}
+
+	var data DBBackupResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &data)...)
+	if data.LastBackupAt.ValueString() != backups[1].LastModified {
+		t.Errorf("expected last_backup_at %s, got %s", backups[1].LastModified, data.LastBackupAt)
+	}
+
+	testCases := []struct {
+		name         string
+		prior        bool
+		onDemand     bool
+		expectBackup bool
+	}{
+		{name: "unchanged flag", prior: true, onDemand: true, expectBackup: false},
+		{name: "flag turned off", prior: true, onDemand: false, expectBackup: false},
+		{name: "flag turned on", prior: false, onDemand: true, expectBackup: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			count := len(backups)
+
+			state := tfsdk.State(plan("db1", tc.prior))
+			resp := fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan("db1", tc.onDemand), State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if took := len(backups) > count; took != tc.expectBackup {
+				t.Errorf("expected backup %t, got %d new backups", tc.expectBackup, len(backups)-count)
+			}
+		})
+	}
+
+	// a removed database removes the resource
+	state := tfsdk.State(plan("missing", false))
+	readResp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+
+	if !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the resource to be removed from state")
+	}
+}
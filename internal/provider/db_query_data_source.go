@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/db_inspector"
+)
+
+const defaultDBQueryMaxRows int64 = 100
+
+// readOnlyStatements lists the statements a query may start with. Anything
+// else is rejected, as data sources must not change the database.
+var readOnlyStatements = []string{
+	"SELECT",
+	"WITH",
+	"SHOW",
+	"EXPLAIN",
+	"DESCRIBE",
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DBQueryDataSource{}
+
+func NewDBQueryDataSource() datasource.DataSource {
+	return &DBQueryDataSource{}
+}
+
+// DBQueryDataSource defines the data source implementation.
+type DBQueryDataSource struct {
+	client db_inspector.ClientInterface
+}
+
+// DBQueryDataSourceModel describes the data source data model.
+type DBQueryDataSourceModel struct {
+	DatabaseID types.String `tfsdk:"database_id"`
+	Query      types.String `tfsdk:"query"`
+	MaxRows    types.Int64  `tfsdk:"max_rows"`
+	Count      types.Int64  `tfsdk:"count"`
+	Rows       types.List   `tfsdk:"rows"`
+}
+
+func (d *DBQueryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_db_query"
+}
+
+func (d *DBQueryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Database query data source, runs a read-only query against a database through the database inspector. " +
+			"Only a single statement starting with " + strings.Join(readOnlyStatements, ", ") + " is accepted.",
+
+		Attributes: map[string]schema.Attribute{
+			"database_id": schema.StringAttribute{
+				MarkdownDescription: "database id",
+				Required:            true,
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "read-only query to run",
+				Required:            true,
+			},
+			"max_rows": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("maximum number of rows to return (default: %d)", defaultDBQueryMaxRows),
+				Optional:            true,
+			},
+			"count": schema.Int64Attribute{
+				MarkdownDescription: "number of rows the query returned, before max_rows is applied",
+				Computed:            true,
+			},
+			"rows": schema.ListAttribute{
+				MarkdownDescription: "returned rows, each encoded as a JSON object; use `jsondecode` to read them",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DBQueryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	if len(providerData.DBInspectorEndpoint) == 0 {
+		resp.Diagnostics.AddError(
+			"Missing Liara Database Inspector Endpoint",
+			"The liara_db_query data source requires the Liara database inspector endpoint. "+
+				"Set the db_inspector_endpoint value in the provider configuration or use the LIARA_DB_INSPECTOR_ENDPOINT environment variable.",
+		)
+
+		return
+	}
+
+	d.client = providerData.DBInspectorClient
+}
+
+func (d *DBQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DBQueryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := data.Query.ValueString()
+	if err := validateReadOnlyQuery(query); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("query"), "Invalid query", err.Error())
+	}
+
+	maxRows := defaultDBQueryMaxRows
+	if !data.MaxRows.IsNull() {
+		maxRows = data.MaxRows.ValueInt64()
+	}
+
+	if maxRows < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_rows"),
+			"Invalid max_rows",
+			fmt.Sprintf("max_rows must be a positive number, got: %d", maxRows),
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := d.client.Queries(ctx, data.DatabaseID.ValueString(), db_inspector.QueriesJSONRequestBody{Query: &query})
+	if err != nil {
+		resp.Diagnostics.AddError("Running query failed", fmt.Sprintf("Unable to run query, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			resp.Diagnostics.AddError("reading response payload failed", err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Running query failed", fmt.Sprintf("Unable to run query, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	responseModel := struct {
+		Count int64             `json:"count"`
+		Data  []json.RawMessage `json:"data"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		resp.Diagnostics.AddError("Decoding query response failed", fmt.Sprintf("Unable to decode query response, got error: %s", err))
+		return
+	}
+
+	if int64(len(responseModel.Data)) > maxRows {
+		responseModel.Data = responseModel.Data[:maxRows]
+	}
+
+	rows := make([]string, 0, len(responseModel.Data))
+	for _, row := range responseModel.Data {
+		rows = append(rows, string(row))
+	}
+
+	data.Count = types.Int64Value(responseModel.Count)
+
+	rowsValue, diags := types.ListValueFrom(ctx, types.StringType, rows)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Rows = rowsValue
+
+	tflog.Trace(ctx, "ran a database query", map[string]any{"rows": len(rows)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// validateReadOnlyQuery rejects queries that are obviously not read-only:
+// queries with more than one statement, and statements not starting with
+// one of the readOnlyStatements.
+func validateReadOnlyQuery(query string) error {
+	statement := strings.TrimSpace(query)
+	statement = strings.TrimSpace(strings.TrimSuffix(statement, ";"))
+
+	if len(statement) == 0 {
+		return fmt.Errorf("query must not be empty")
+	}
+
+	if strings.Contains(statement, ";") {
+		return fmt.Errorf("query must be a single statement")
+	}
+
+	keyword := strings.ToUpper(strings.TrimLeft(strings.Fields(statement)[0], "("))
+	for _, allowed := range readOnlyStatements {
+		if keyword == allowed {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("only read-only queries starting with %s are allowed, got: %s", strings.Join(readOnlyStatements, ", "), keyword)
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/db_inspector"
+)
+
+func TestDBQueryDataSourceRead(t *testing.T) {
+	var requestedPath, requestedQuery string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+
+		var body db_inspector.QueryRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err == nil && body.Query != nil {
+			requestedQuery = *body.Query
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"count":3,"data":[{"id":1,"name":"a"},{"id":2,"name":"b"},{"id":3,"name":"c"}],"schema":{}}`))
+	}))
+	defer server.Close()
+
+	client, err := db_inspector.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	d := &DBQueryDataSource{client: client}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"database_id": tftypes.NewValue(tftypes.String, "db-id"),
+		"query":       tftypes.NewValue(tftypes.String, "select id, name from users;"),
+		"max_rows":    tftypes.NewValue(tftypes.Number, 2),
+	})
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+	}
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	d.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if requestedPath != "/v1/databases/db-id/queries" {
+		t.Errorf("unexpected path %q", requestedPath)
+	}
+
+	if requestedQuery != "select id, name from users;" {
+		t.Errorf("unexpected query %q", requestedQuery)
+	}
+
+	var data DBQueryDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if data.Count.ValueInt64() != 3 {
+		t.Errorf("expected count 3, got %d", data.Count.ValueInt64())
+	}
+
+	var rows []string
+	resp.Diagnostics.Append(data.Rows.ElementsAs(ctx, &rows, false)...)
+
+	expectRows := []string{`{"id":1,"name":"a"}`, `{"id":2,"name":"b"}`}
+	if !reflect.DeepEqual(rows, expectRows) {
+		t.Errorf("expected rows %v, got %v", expectRows, rows)
+	}
+}
+
+func TestDBQueryDataSourceRejectsWriteQueries(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "delete", query: "DELETE FROM users"},
+		{name: "drop", query: "drop table users"},
+		{name: "chained statements", query: "SELECT 1; DROP TABLE users"},
+		{name: "empty", query: "  ;"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requested := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = true
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer server.Close()
+
+			client, err := db_inspector.NewClient(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			d := &DBQueryDataSource{client: client}
+
+			schemaResp := datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+			config := testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"database_id": tftypes.NewValue(tftypes.String, "db-id"),
+				"query":       tftypes.NewValue(tftypes.String, tc.query),
+			})
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+
+			d.Read(ctx, req, &resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected an error for query %q", tc.query)
+			}
+
+			if requested {
+				t.Errorf("expected no request for query %q", tc.query)
+			}
+		})
+	}
+}
+
+func TestValidateReadOnlyQuery(t *testing.T) {
+	for _, query := range []string{
+		"SELECT 1",
+		"  select * from users;  ",
+		"WITH t AS (SELECT 1) SELECT * FROM t",
+		"(select 1)",
+		"show tables",
+	} {
+		if err := validateReadOnlyQuery(query); err != nil {
+			t.Errorf("expected %q to be accepted, got: %s", query, err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/dns"
+)
+
+// dnsZoneActiveStatus is the status of a zone whose nameservers are
+// verified.
+const dnsZoneActiveStatus = "ACTIVE"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DNSZoneDataSource{}
+
+func NewDNSZoneDataSource() datasource.DataSource {
+	return &DNSZoneDataSource{}
+}
+
+// DNSZoneDataSource defines the data source implementation.
+type DNSZoneDataSource struct {
+	client dns.ClientInterface
+}
+
+// DNSZoneDataSourceModel describes the data source data model.
+type DNSZoneDataSourceModel struct {
+	Name               types.String `tfsdk:"name"`
+	ID                 types.String `tfsdk:"id"`
+	Status             types.String `tfsdk:"status"`
+	Verified           types.Bool   `tfsdk:"verified"`
+	Nameservers        types.List   `tfsdk:"nameservers"`
+	CurrentNameservers types.List   `tfsdk:"current_nameservers"`
+}
+
+func (d *DNSZoneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone"
+}
+
+func (d *DNSZoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DNS zone data source, reads a domain registered with the Liara DNS service and the nameservers to set at its registrar",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "domain name of the zone, e.g. `example.com`",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "zone id",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "zone status, one of CREATING, PENDING, ACTIVE or DELETING",
+				Computed:            true,
+			},
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "whether the nameservers of the domain are verified to point to Liara",
+				Computed:            true,
+			},
+			"nameservers": schema.ListAttribute{
+				MarkdownDescription: "Liara nameservers to set at the registrar of the domain",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"current_nameservers": schema.ListAttribute{
+				MarkdownDescription: "nameservers the domain currently points to, as of the last check",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DNSZoneDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.DNSClient
+}
+
+func (d *DNSZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DNSZoneDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := d.client.GetZone(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Reading DNS zone failed", fmt.Sprintf("Unable to read DNS zone, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode == http.StatusNotFound {
+		resp.Diagnostics.AddError(
+			"DNS zone not found",
+			fmt.Sprintf("Domain %q isn't registered with the Liara DNS service, add it as a zone first.", data.Name.ValueString()),
+		)
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			resp.Diagnostics.AddError("reading response payload failed", err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Reading DNS zone failed", fmt.Sprintf("Unable to read DNS zone, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	responseModel := struct {
+		Data struct {
+			ID                 string   `json:"id"`
+			Status             string   `json:"status"`
+			NameServers        []string `json:"nameServers"`
+			CurrentNameServers []string `json:"currentNameServers"`
+		} `json:"data"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		resp.Diagnostics.AddError("Decoding read response failed", fmt.Sprintf("Unable to decode read response, got error: %s", err))
+		return
+	}
+
+	zone := responseModel.Data
+
+	data.ID = types.StringValue(zone.ID)
+	data.Status = types.StringValue(zone.Status)
+	data.Verified = types.BoolValue(zone.Status == dnsZoneActiveStatus)
+
+	nameservers, diags := types.ListValueFrom(ctx, types.StringType, zone.NameServers)
+	resp.Diagnostics.Append(diags...)
+	currentNameservers, diags := types.ListValueFrom(ctx, types.StringType, zone.CurrentNameServers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Nameservers = nameservers
+	data.CurrentNameservers = currentNameservers
+
+	tflog.Trace(ctx, "read dns zone data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/dns"
+)
+
+func TestDNSZoneDataSourceRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/zones/example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"zone not found"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":"success","data":{
+			"id":"zone-id",
+			"name":"example.com",
+			"status":"ACTIVE",
+			"nameServers":["ns1.liara.ir","ns2.liara.ir"],
+			"currentNameServers":["ns1.liara.ir","ns2.liara.ir"]
+		}}`))
+	}))
+	defer server.Close()
+
+	client, err := dns.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	d := &DNSZoneDataSource{client: client}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	read := func(name string) datasource.ReadResponse {
+		req := datasource.ReadRequest{
+			Config: tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw: testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"name": tftypes.NewValue(tftypes.String, name),
+				}),
+			},
+		}
+		resp := datasource.ReadResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema},
+		}
+
+		d.Read(ctx, req, &resp)
+
+		return resp
+	}
+
+	resp := read("example.com")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data DNSZoneDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if data.ID.ValueString() != "zone-id" || data.Status.ValueString() != "ACTIVE" {
+		t.Errorf("unexpected zone %s with status %s", data.ID, data.Status)
+	}
+
+	if !data.Verified.ValueBool() {
+		t.Error("expected the zone to be verified")
+	}
+
+	var nameservers []string
+	resp.Diagnostics.Append(data.Nameservers.ElementsAs(ctx, &nameservers, false)...)
+	if expected := []string{"ns1.liara.ir", "ns2.liara.ir"}; !reflect.DeepEqual(nameservers, expected) {
+		t.Errorf("expected nameservers %v, got %v", expected, nameservers)
+	}
+
+	resp = read("missing.com")
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "DNS zone not found" {
+		t.Errorf("expected a DNS zone not found diagnostic, got: %v", resp.Diagnostics)
+	}
+}
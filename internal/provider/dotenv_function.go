@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// envKeyPattern matches the env keys accepted by Liara.
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// dotenvEscapes maps the escape sequences of double quoted values to the
+// characters they stand for.
+var dotenvEscapes = map[byte]string{
+	'n':  "\n",
+	'r':  "\r",
+	't':  "\t",
+	'"':  `"`,
+	'\\': `\`,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DotenvFunction{}
+
+func NewDotenvFunction() function.Function {
+	return &DotenvFunction{}
+}
+
+// DotenvFunction defines the function implementation.
+type DotenvFunction struct{}
+
+func (f *DotenvFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dotenv"
+}
+
+func (f *DotenvFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a .env file",
+		MarkdownDescription: "Parses a `.env` file into a map, e.g. for the `envs` attribute of `liara_app`. Each line holds a `KEY=VALUE` pair, optionally prefixed by `export `. " +
+			"Blank lines and lines starting with `#` are skipped, as are comments after unquoted values. Values may be single quoted, taken literally, or double quoted, " +
+			"where `\\n`, `\\r`, `\\t`, `\\\"` and `\\\\` are unescaped.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "path of the .env file",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *DotenvFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &path))
+	if resp.Error != nil {
+		return
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unable to read %s: %s", path, err))
+		return
+	}
+
+	envs, err := parseDotenv(string(content))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unable to parse %s: %s", path, err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, envs))
+}
+
+// parseDotenv parses the content of a .env file, later keys override
+// earlier ones.
+func parseDotenv(content string) (map[string]string, error) {
+	envs := make(map[string]string)
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", i+1)
+		}
+
+		key = strings.TrimSpace(key)
+		if !envKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid key %q, keys must match %s", i+1, key, envKeyPattern)
+		}
+
+		value, err := parseDotenvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", i+1, err)
+		}
+
+		envs[key] = value
+	}
+
+	return envs, nil
+}
+
+// parseDotenvValue unquotes a value, or strips the comment following it when
+// it isn't quoted.
+func parseDotenvValue(value string) (string, error) {
+	if len(value) == 0 {
+		return value, nil
+	}
+
+	var unquoted strings.Builder
+	var rest string
+
+	switch quote := value[0]; quote {
+	case '\'':
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated single quoted value")
+		}
+
+		unquoted.WriteString(value[1 : end+1])
+		rest = value[end+2:]
+	case '"':
+		closed := false
+		i := 1
+		for ; i < len(value); i++ {
+			if value[i] == '"' {
+				closed = true
+				break
+			}
+
+			if value[i] != '\\' {
+				unquoted.WriteByte(value[i])
+				continue
+			}
+
+			if i+1 == len(value) {
+				break
+			}
+
+			escaped, ok := dotenvEscapes[value[i+1]]
+			if !ok {
+				return "", fmt.Errorf("invalid escape sequence \\%c", value[i+1])
+			}
+
+			unquoted.WriteString(escaped)
+			i++
+		}
+
+		if !closed {
+			return "", fmt.Errorf("unterminated double quoted value")
+		}
+
+		rest = value[i+1:]
+	default:
+		if comment := strings.Index(value, " #"); comment >= 0 {
+			value = value[:comment]
+		}
+
+		return strings.TrimSpace(value), nil
+	}
+
+	if rest = strings.TrimSpace(rest); len(rest) > 0 && !strings.HasPrefix(rest, "#") {
+		return "", fmt.Errorf("unexpected %q after the quoted value", rest)
+	}
+
+	return unquoted.String(), nil
+}
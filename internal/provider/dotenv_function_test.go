@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDotenvFunctionRun(t *testing.T) {
+	content := strings.Join([]string{
+		"# database settings",
+		"DB_HOST=db.example.com",
+		"",
+		"  export DB_PORT = 5432  ",
+		"DB_NAME=app # inline comment",
+		"URL=https://example.com/#anchor",
+		`DB_PASSWORD="p@ss #not a comment"`,
+		`GREETING="hello\n\"world\""  # comment`,
+		`LITERAL='$HOME \n stays'`,
+		"EMPTY=",
+		"DB_HOST=override.example.com\r",
+	}, "\n")
+
+	resp := testDotenv(t, content)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	expected := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"DB_HOST":     types.StringValue("override.example.com"),
+		"DB_PORT":     types.StringValue("5432"),
+		"DB_NAME":     types.StringValue("app"),
+		"URL":         types.StringValue("https://example.com/#anchor"),
+		"DB_PASSWORD": types.StringValue("p@ss #not a comment"),
+		"GREETING":    types.StringValue("hello\n\"world\""),
+		"LITERAL":     types.StringValue(`$HOME \n stays`),
+		"EMPTY":       types.StringValue(""),
+	})
+	if !resp.Result.Value().Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+	}
+}
+
+func TestDotenvFunctionRunMalformed(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		line    string
+	}{
+		{name: "missing equal sign", content: "A=1\nB", line: "line 2"},
+		{name: "invalid key", content: "# comment\n\n1A=1", line: "line 3"},
+		{name: "unterminated double quote", content: `A="1`, line: "line 1"},
+		{name: "unterminated single quote", content: "A=1\nB='2", line: "line 2"},
+		{name: "text after a quoted value", content: `A="1" 2`, line: "line 1"},
+		{name: "invalid escape sequence", content: `A="\x"`, line: "line 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testDotenv(t, tc.content)
+			if resp.Error == nil {
+				t.Fatal("expected an error")
+			}
+
+			if !strings.Contains(resp.Error.Error(), tc.line) {
+				t.Errorf("expected the error to mention %s, got: %s", tc.line, resp.Error)
+			}
+		})
+	}
+}
+
+func TestDotenvFunctionRunMissingFile(t *testing.T) {
+	resp := testDotenvPath(filepath.Join(t.TempDir(), "missing.env"))
+	if resp.Error == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func testDotenv(t *testing.T, content string) function.RunResponse {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return testDotenvPath(path)
+}
+
+func testDotenvPath(path string) function.RunResponse {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue(path),
+		}),
+	}
+	resp := function.RunResponse{
+		Result: function.NewResultData(types.MapUnknown(types.StringType)),
+	}
+
+	(&DotenvFunction{}).Run(context.Background(), req, &resp)
+
+	return resp
+}
@@ -0,0 +1,367 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/file_browser"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FileBrowserUploadResource{}
+var _ resource.ResourceWithModifyPlan = &FileBrowserUploadResource{}
+
+func NewFileBrowserUploadResource() resource.Resource {
+	return &FileBrowserUploadResource{}
+}
+
+// FileBrowserUploadResource defines the resource implementation.
+type FileBrowserUploadResource struct {
+	client  file_browser.ClientInterface
+	timeout time.Duration
+}
+
+// FileBrowserUploadResourceModel describes the resource data model.
+type FileBrowserUploadResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	AppName    types.String `tfsdk:"app_name"`
+	DiskName   types.String `tfsdk:"disk_name"`
+	RemotePath types.String `tfsdk:"remote_path"`
+	Source     types.String `tfsdk:"source"`
+	Checksum   types.String `tfsdk:"checksum"`
+}
+
+func (r *FileBrowserUploadResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file_browser_upload"
+}
+
+func (r *FileBrowserUploadResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "File browser upload resource, uploads a local file to a disk of an app. The provider `file_browser_endpoint` must be set to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "identifier, in the form of `app_name/disk_name/remote_path`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_name": schema.StringAttribute{
+				MarkdownDescription: "app name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"disk_name": schema.StringAttribute{
+				MarkdownDescription: "name of the app disk to upload the file to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"remote_path": schema.StringAttribute{
+				MarkdownDescription: "path of the file on the disk, e.g. `/config/app.yaml`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "path of the local file to upload",
+				Required:            true,
+			},
+			"checksum": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the local file, the file is uploaded again when it changes",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *FileBrowserUploadResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	if len(providerData.FileBrowserEndpoint) == 0 {
+		resp.Diagnostics.AddError(
+			"Missing Liara File Browser Endpoint",
+			"The liara_file_browser_upload resource requires the Liara file browser endpoint. "+
+				"Set the file_browser_endpoint value in the provider configuration or use the LIARA_FILE_BROWSER_ENDPOINT environment variable.",
+		)
+
+		return
+	}
+
+	r.client = providerData.FileBrowserClient
+	r.timeout = providerData.Timeout
+}
+
+// ModifyPlan computes the checksum of the source file, so changes of the file
+// cause an upload.
+func (r *FileBrowserUploadResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data FileBrowserUploadResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Source.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, fwpath.Root("checksum"), types.StringUnknown())...)
+		return
+	}
+
+	content, diags := fileBrowserSourceContent(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, fwpath.Root("checksum"), types.StringValue(fileChecksum(content)))...)
+}
+
+func (r *FileBrowserUploadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data FileBrowserUploadResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.upload(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s%s", data.AppName.ValueString(), data.DiskName.ValueString(), path.Join("/", data.RemotePath.ValueString())))
+
+	tflog.Trace(ctx, "created a file browser upload resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FileBrowserUploadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data FileBrowserUploadResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.GetList(ctx, data.AppName.ValueString(), data.DiskName.ValueString(), &file_browser.GetListParams{
+		Path: path.Join("/", data.RemotePath.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Reading file failed", fmt.Sprintf("Unable to read file, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	// the file was removed outside of terraform.
+	if response.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			resp.Diagnostics.AddError("reading response payload failed", err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Reading file failed", fmt.Sprintf("Unable to read file, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	tflog.Trace(ctx, "read file browser upload resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FileBrowserUploadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data, state FileBrowserUploadResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// moving the source file without changing it doesn't need an upload.
+	if !data.Checksum.Equal(state.Checksum) {
+		r.upload(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated a file browser upload resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FileBrowserUploadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data FileBrowserUploadResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.Delete(ctx, data.AppName.ValueString(), data.DiskName.ValueString(), &file_browser.DeleteParams{
+		Path: path.Join("/", data.RemotePath.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Deleting file failed", fmt.Sprintf("Unable to delete file, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound:
+		return
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		resp.Diagnostics.AddError("reading delete response payload failed", err.Error())
+
+		return
+	}
+
+	resp.Diagnostics.AddError("Deleting file failed", fmt.Sprintf("Unable to delete file, got error: %s", responseErrorMessage(response, body)))
+}
+
+// upload sends the source file to its directory on the disk, overwriting the
+// existing file, and sets its checksum.
+func (r *FileBrowserUploadResource) upload(ctx context.Context, data *FileBrowserUploadResourceModel, diagnostics *diag.Diagnostics) {
+	content, diags := fileBrowserSourceContent(data)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	remotePath := path.Join("/", data.RemotePath.ValueString())
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", path.Base(remotePath))
+	if err == nil {
+		_, err = part.Write(content)
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		diagnostics.AddError("Encoding upload request failed", fmt.Sprintf("Unable to encode upload request, got error: %s", err))
+		return
+	}
+
+	overwrite := "yes"
+	response, err := r.client.UploadWithBody(ctx, data.AppName.ValueString(), data.DiskName.ValueString(), &file_browser.UploadParams{
+		Path:      path.Dir(remotePath),
+		Overwrite: &overwrite,
+	}, writer.FormDataContentType(), &body)
+	if err != nil {
+		diagnostics.AddError("Uploading file failed", fmt.Sprintf("Unable to upload file, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading upload response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Uploading file failed", fmt.Sprintf("Unable to upload file, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	data.Checksum = types.StringValue(fileChecksum(content))
+}
+
+// fileBrowserSourceContent returns the content of the source file.
+func fileBrowserSourceContent(data *FileBrowserUploadResourceModel) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	content, err := os.ReadFile(data.Source.ValueString())
+	if err != nil {
+		diags.AddAttributeError(fwpath.Root("source"), "Reading source file failed", fmt.Sprintf("Unable to read %s, got error: %s", data.Source.ValueString(), err))
+		return nil, diags
+	}
+
+	return content, diags
+}
+
+// fileChecksum returns the hex encoded SHA-256 hash of the content.
+func fileChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
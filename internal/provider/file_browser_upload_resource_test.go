@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/file_browser"
+)
+
+// fakeFileBrowser is an in-memory disk served over http.
+type fakeFileBrowser struct {
+	files   map[string]string
+	uploads int
+}
+
+func (s *fakeFileBrowser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const prefix = "/v1/projects/my-app/disks/data/"
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == prefix+"upload":
+		if r.URL.Query().Get("overwrite") != "yes" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		content, _ := io.ReadAll(file)
+		s.files[path.Join(r.URL.Query().Get("path"), header.Filename)] = string(content)
+		s.uploads++
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet && r.URL.Path == prefix+"list":
+		if _, ok := s.files[r.URL.Query().Get("path")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"isDir":false}`))
+	case r.Method == http.MethodDelete && r.URL.Path == prefix+"delete":
+		delete(s.files, r.URL.Query().Get("path"))
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestFileBrowserUploadResource(t *testing.T) {
+	disk := &fakeFileBrowser{files: map[string]string{}}
+
+	server := httptest.NewServer(disk)
+	defer server.Close()
+
+	client, err := file_browser.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := filepath.Join(t.TempDir(), "app.yaml")
+	writeSource := func(t *testing.T, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(source, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	r := &FileBrowserUploadResource{client: client}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	// plan returns the plan of the source file, as modified by the resource.
+	plan := func(t *testing.T, state tfsdk.State) tfsdk.Plan {
+		t.Helper()
+
+		proposed := tfsdk.Plan{
+			Schema: schemaResp.Schema,
+			Raw: testObjectValue(objectType, map[string]tftypes.Value{
+				"app_name":    tftypes.NewValue(tftypes.String, "my-app"),
+				"disk_name":   tftypes.NewValue(tftypes.String, "data"),
+				"remote_path": tftypes.NewValue(tftypes.String, "config/app.yaml"),
+				"source":      tftypes.NewValue(tftypes.String, source),
+				"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"checksum":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+		}
+
+		resp := fwresource.ModifyPlanResponse{Plan: proposed}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: proposed, State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		return resp.Plan
+	}
+
+	emptyState := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+
+	// create uploads the file
+	writeSource(t, "port: 8080")
+
+	createResp := fwresource.CreateResponse{State: emptyState}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan(t, emptyState)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if disk.files["/config/app.yaml"] != "port: 8080" {
+		t.Fatalf("expected the file to be uploaded, got %v", disk.files)
+	}
+
+	var data FileBrowserUploadResourceModel
+	createResp.Diagnostics.Append(createResp.State.Get(ctx, &data)...)
+	if data.Checksum.ValueString() != fileChecksum([]byte("port: 8080")) {
+		t.Errorf("expected the checksum of the file, got %s", data.Checksum)
+	}
+
+	if data.ID.ValueString() != "my-app/data/config/app.yaml" {
+		t.Errorf("expected id my-app/data/config/app.yaml, got %s", data.ID)
+	}
+
+	// an unchanged file isn't uploaded again
+	unchangedPlan := plan(t, createResp.State)
+	unchangedResp := fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: unchangedPlan, State: createResp.State}, &unchangedResp)
+	if unchangedResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", unchangedResp.Diagnostics)
+	}
+
+	if disk.uploads != 1 {
+		t.Fatalf("expected no upload of an unchanged file, got %d uploads", disk.uploads)
+	}
+
+	// a checksum change uploads the file again
+	writeSource(t, "port: 9090")
+
+	updatePlan := plan(t, createResp.State)
+	updateResp := fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: updatePlan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if disk.uploads != 2 || disk.files["/config/app.yaml"] != "port: 9090" {
+		t.Fatalf("expected the changed file to be uploaded, got %d uploads and %v", disk.uploads, disk.files)
+	}
+
+	// delete removes the remote file
+	deleteResp := fwresource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", deleteResp.Diagnostics)
+	}
+
+	if _, ok := disk.files["/config/app.yaml"]; ok {
+		t.Errorf("expected the file to be deleted")
+	}
+
+	// a removed file is removed from the state
+	readResp := fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+
+	if !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the removed file to be removed from the state")
+	}
+}
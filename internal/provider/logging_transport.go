@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedHeaders lists the headers whose values are never logged.
+var redactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// loggingTransport logs every API request and its response. Request and
+// response bodies are never logged, as they may carry sensitive values such
+// as app envs.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+// newLoggingTransport wraps next (or http.DefaultTransport when nil) with logging.
+func newLoggingTransport(next http.RoundTripper) *loggingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &loggingTransport{
+		next: next,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	fields := map[string]any{
+		"method":  req.Method,
+		"url":     req.URL.Redacted(),
+		"headers": redactHeaders(req.Header),
+	}
+
+	response, err := t.next.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "API request failed", fields)
+
+		return response, err
+	}
+
+	fields["status"] = response.StatusCode
+	tflog.Debug(ctx, "API request", fields)
+
+	return response, err
+}
+
+// redactHeaders returns a copy of the headers, with sensitive values redacted.
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for key, values := range header {
+		redacted[key] = strings.Join(values, ", ")
+	}
+
+	for _, key := range redactedHeaders {
+		if len(header.Values(key)) > 0 {
+			redacted[http.CanonicalHeaderKey(key)] = "[REDACTED]"
+		}
+	}
+
+	return redacted
+}
+
+// debugLoggingEnabled reports whether terraform logs the provider at debug
+// level or lower, so requests are only logged when the logs are shown.
+func debugLoggingEnabled() bool {
+	for _, key := range []string{"TF_LOG_PROVIDER", "TF_LOG"} {
+		switch strings.ToUpper(os.Getenv(key)) {
+		case "DEBUG", "TRACE", "JSON":
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/projects", strings.NewReader(`{"envs":[{"key":"SECRET","value":"s3cr3t"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer my-token")
+
+	client := &http.Client{Transport: newLoggingTransport(nil)}
+
+	response, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	logged := output.String()
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d: %s", len(entries), logged)
+	}
+
+	entry := entries[0]
+	if entry["@level"] != "debug" || entry["method"] != http.MethodPost || entry["status"] != float64(http.StatusCreated) {
+		t.Errorf("unexpected log entry: %v", entry)
+	}
+
+	if url, _ := entry["url"].(string); !strings.HasSuffix(url, "/v1/projects") {
+		t.Errorf("expected the url to be logged, got %v", entry["url"])
+	}
+
+	headers, _ := entry["headers"].(map[string]any)
+	if headers["Authorization"] != "[REDACTED]" {
+		t.Errorf("expected the authorization header to be redacted, got %v", headers["Authorization"])
+	}
+
+	for _, secret := range []string{"my-token", "s3cr3t"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected %q not to be logged, got: %s", secret, logged)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+const (
+	objectStorageVirtualHostedStyle = "virtual-hosted"
+	objectStoragePathStyle          = "path"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ObjectStorageEndpointFunction{}
+
+func NewObjectStorageEndpointFunction() function.Function {
+	return &ObjectStorageEndpointFunction{}
+}
+
+// ObjectStorageEndpointFunction defines the function implementation.
+type ObjectStorageEndpointFunction struct{}
+
+func (f *ObjectStorageEndpointFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_storage_endpoint"
+}
+
+func (f *ObjectStorageEndpointFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Compute the S3 endpoint of a bucket",
+		MarkdownDescription: "Returns the S3-compatible endpoint URL of an object storage bucket in a region, e.g. `https://my-bucket.storage.iran.liara.space`, to use with S3 clients such as the AWS provider.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "bucket",
+				MarkdownDescription: "bucket name",
+			},
+			function.StringParameter{
+				Name:                "region",
+				MarkdownDescription: fmt.Sprintf("Liara region of the bucket, one of %s", strings.Join(liaraRegionNames, ", ")),
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "style",
+			MarkdownDescription: fmt.Sprintf("URL style, `%s` (default) for `https://<bucket>.<host>` or `%s` for `https://<host>/<bucket>`", objectStorageVirtualHostedStyle, objectStoragePathStyle),
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ObjectStorageEndpointFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var bucket, regionName string
+	var style []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &bucket, &regionName, &style))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(bucket) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "bucket must not be empty")
+		return
+	}
+
+	region, ok := liaraRegions[regionName]
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("region must be one of %s, got: %s", strings.Join(liaraRegionNames, ", "), regionName))
+		return
+	}
+
+	if len(style) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, "style can be passed at most once")
+		return
+	}
+
+	urlStyle := objectStorageVirtualHostedStyle
+	if len(style) == 1 {
+		urlStyle = style[0]
+	}
+
+	if urlStyle != objectStorageVirtualHostedStyle && urlStyle != objectStoragePathStyle {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("style must be one of %s, got: %s", strings.Join([]string{objectStorageVirtualHostedStyle, objectStoragePathStyle}, ", "), urlStyle))
+		return
+	}
+
+	endpoint := fmt.Sprintf("https://%s.%s", bucket, region.ObjectStorageHost)
+	if urlStyle == objectStoragePathStyle {
+		endpoint = fmt.Sprintf("https://%s/%s", region.ObjectStorageHost, bucket)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, endpoint))
+}
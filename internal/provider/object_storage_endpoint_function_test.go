@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestObjectStorageEndpointFunctionRun(t *testing.T) {
+	testCases := []struct {
+		name        string
+		region      string
+		style       []attr.Value
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "iran region default style",
+			region:   "iran",
+			expected: "https://my-bucket.storage.iran.liara.space",
+		},
+		{
+			name:     "iran region virtual-hosted style",
+			region:   "iran",
+			style:    []attr.Value{types.StringValue("virtual-hosted")},
+			expected: "https://my-bucket.storage.iran.liara.space",
+		},
+		{
+			name:     "iran region path style",
+			region:   "iran",
+			style:    []attr.Value{types.StringValue("path")},
+			expected: "https://storage.iran.liara.space/my-bucket",
+		},
+		{
+			name:     "germany region virtual-hosted style",
+			region:   "germany",
+			style:    []attr.Value{types.StringValue("virtual-hosted")},
+			expected: "https://my-bucket.storage.liara.space",
+		},
+		{
+			name:     "germany region path style",
+			region:   "germany",
+			style:    []attr.Value{types.StringValue("path")},
+			expected: "https://storage.liara.space/my-bucket",
+		},
+		{
+			name:        "unknown region",
+			region:      "mars",
+			expectError: true,
+		},
+		{
+			name:        "unknown style",
+			region:      "iran",
+			style:       []attr.Value{types.StringValue("dualstack")},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := testObjectStorageEndpoint(t, tc.region, tc.style)
+			if tc.expectError {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			result, ok := resp.Result.Value().(types.String)
+			if !ok {
+				t.Fatalf("unexpected result type %T", resp.Result.Value())
+			}
+
+			if result.ValueString() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, result.ValueString())
+			}
+		})
+	}
+}
+
+func TestObjectStorageEndpointFunctionRunEveryRegion(t *testing.T) {
+	for _, name := range liaraRegionNames {
+		host := liaraRegions[name].ObjectStorageHost
+
+		expected := map[string]string{
+			"virtual-hosted": "https://my-bucket." + host,
+			"path":           "https://" + host + "/my-bucket",
+		}
+
+		for style, endpoint := range expected {
+			t.Run(name+" "+style, func(t *testing.T) {
+				resp := testObjectStorageEndpoint(t, name, []attr.Value{types.StringValue(style)})
+				if resp.Error != nil {
+					t.Fatalf("unexpected error: %s", resp.Error)
+				}
+
+				if !resp.Result.Value().Equal(types.StringValue(endpoint)) {
+					t.Errorf("expected %s, got %s", endpoint, resp.Result.Value())
+				}
+			})
+		}
+	}
+}
+
+func testObjectStorageEndpoint(t *testing.T, region string, style []attr.Value) function.RunResponse {
+	t.Helper()
+
+	elementTypes := make([]attr.Type, len(style))
+	for i := range elementTypes {
+		elementTypes[i] = types.StringType
+	}
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("my-bucket"),
+			types.StringValue(region),
+			types.TupleValueMust(elementTypes, style),
+		}),
+	}
+	resp := function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+
+	(&ObjectStorageEndpointFunction{}).Run(context.Background(), req, &resp)
+
+	return resp
+}
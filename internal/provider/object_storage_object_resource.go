@@ -0,0 +1,410 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/object_storage"
+)
+
+const defaultObjectContentType = "application/octet-stream"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ObjectStorageObjectResource{}
+var _ resource.ResourceWithModifyPlan = &ObjectStorageObjectResource{}
+
+func NewObjectStorageObjectResource() resource.Resource {
+	return &ObjectStorageObjectResource{}
+}
+
+// ObjectStorageObjectResource defines the resource implementation.
+type ObjectStorageObjectResource struct {
+	client     object_storage.ClientInterface
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// ObjectStorageObjectResourceModel describes the resource data model.
+type ObjectStorageObjectResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Bucket      types.String `tfsdk:"bucket"`
+	Key         types.String `tfsdk:"key"`
+	Source      types.String `tfsdk:"source"`
+	Content     types.String `tfsdk:"content"`
+	ContentType types.String `tfsdk:"content_type"`
+	ETag        types.String `tfsdk:"etag"`
+}
+
+func (r *ObjectStorageObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_storage_object"
+}
+
+func (r *ObjectStorageObjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Object storage object resource, uploads a file to a bucket",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "identifier, in the form of `bucket/key`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "bucket name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "object key (path in the bucket)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "path of a local file to upload, conflicts with `content`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content")),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "inline content to upload, conflicts with `source`",
+				Optional:            true,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("content type of the object (default: `%s`)", defaultObjectContentType),
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultObjectContentType),
+			},
+			"etag": schema.StringAttribute{
+				MarkdownDescription: "MD5 hash of the object content, the object is uploaded again when it changes",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ObjectStorageObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*LiaraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.ObjectStorageClient
+	r.httpClient = providerData.HTTPClient
+	r.timeout = providerData.Timeout
+}
+
+// ModifyPlan computes the etag of the configured content, so changes of a
+// source file, or of the object outside of terraform, cause an upload.
+func (r *ObjectStorageObjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ObjectStorageObjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Content.IsUnknown() || data.Source.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("etag"), types.StringUnknown())...)
+		return
+	}
+
+	content, diags := objectContent(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("etag"), types.StringValue(objectETag(content)))...)
+}
+
+func (r *ObjectStorageObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data ObjectStorageObjectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.upload(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Bucket.ValueString(), data.Key.ValueString()))
+
+	tflog.Trace(ctx, "created an object storage object resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectStorageObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data ObjectStorageObjectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.GetStatObject(ctx, data.Bucket.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Reading object failed", fmt.Sprintf("Unable to read object, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	// the object was removed outside of terraform.
+	if response.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			resp.Diagnostics.AddError("reading response payload failed", err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Reading object failed", fmt.Sprintf("Unable to read object, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	responseModel := struct {
+		Data struct {
+			Object struct {
+				ETag     string `json:"etag"`
+				MetaData struct {
+					ContentType string `json:"content-type"`
+				} `json:"metaData"`
+			} `json:"object"`
+		} `json:"data"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		resp.Diagnostics.AddError("Decoding read response failed", fmt.Sprintf("Unable to decode read response, got error: %s", err))
+		return
+	}
+
+	// a different remote etag means the object was changed outside of
+	// terraform, which is planned as an upload of the configured content.
+	data.ETag = types.StringValue(strings.Trim(responseModel.Data.Object.ETag, `"`))
+	if contentType := responseModel.Data.Object.MetaData.ContentType; len(contentType) > 0 {
+		data.ContentType = types.StringValue(contentType)
+	}
+
+	tflog.Trace(ctx, "read object storage object resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectStorageObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data ObjectStorageObjectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.upload(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated an object storage object resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectStorageObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, done := withOperationTimeout(ctx, r.timeout, &resp.Diagnostics)
+	defer done()
+
+	var data ObjectStorageObjectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.DeleteObject(ctx, data.Bucket.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Deleting object failed", fmt.Sprintf("Unable to delete object, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			resp.Diagnostics.AddError("reading delete response payload failed", err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Deleting object failed", fmt.Sprintf("Unable to delete object, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+}
+
+// upload puts the configured content to the presigned upload url of the
+// object and sets its etag.
+func (r *ObjectStorageObjectResource) upload(ctx context.Context, data *ObjectStorageObjectResourceModel, diagnostics *diag.Diagnostics) {
+	content, diags := objectContent(data)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.UploadObject(ctx, data.Bucket.ValueString(), data.Key.ValueString())
+	if err != nil {
+		diagnostics.AddError("Uploading object failed", fmt.Sprintf("Unable to get upload url, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading upload response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Uploading object failed", fmt.Sprintf("Unable to get upload url, got error: %s", responseErrorMessage(response, body)))
+		return
+	}
+
+	responseModel := struct {
+		Data struct {
+			URL string `json:"url"`
+		} `json:"data"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		diagnostics.AddError("Decoding upload response failed", fmt.Sprintf("Unable to decode upload response, got error: %s", err))
+		return
+	}
+
+	// the url is presigned, so the request isn't sent through the API client.
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, responseModel.Data.URL, bytes.NewReader(content))
+	if err != nil {
+		diagnostics.AddError("Uploading object failed", fmt.Sprintf("Unable to create upload request, got error: %s", err))
+		return
+	}
+	request.Header.Set("Content-Type", data.ContentType.ValueString())
+
+	httpClient := r.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	uploadResponse, err := httpClient.Do(request)
+	if err != nil {
+		diagnostics.AddError("Uploading object failed", fmt.Sprintf("Unable to upload object, got error: %s", err))
+		return
+	}
+	defer closeResponseBody(uploadResponse.Body)
+
+	if uploadResponse.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(uploadResponse.Body)
+		if err != nil {
+			diagnostics.AddError("reading upload response payload failed", err.Error())
+
+			return
+		}
+
+		diagnostics.AddError("Uploading object failed", fmt.Sprintf("Unable to upload object, got error: %s", string(body)))
+		return
+	}
+
+	data.ETag = types.StringValue(objectETag(content))
+}
+
+// objectContent returns the configured content of the object.
+func objectContent(data *ObjectStorageObjectResourceModel) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if data.Source.IsNull() {
+		return []byte(data.Content.ValueString()), diags
+	}
+
+	content, err := os.ReadFile(data.Source.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("source"), "Reading source file failed", fmt.Sprintf("Unable to read %s, got error: %s", data.Source.ValueString(), err))
+		return nil, diags
+	}
+
+	return content, diags
+}
+
+// objectETag returns the etag of an object uploaded in a single part.
+func objectETag(content []byte) string {
+	sum := md5.Sum(content)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/object_storage"
+)
+
+// fakeObjectStorage is an in-memory bucket served over http.
+type fakeObjectStorage struct {
+	objects map[string]string
+	uploads int
+}
+
+func (s *fakeObjectStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/buckets/my-bucket/upload/"):
+		key := strings.TrimPrefix(r.URL.Path, "/api/v1/buckets/my-bucket/upload/")
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"url":"http://%s/presigned/%s"}}`, r.Host, key)
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/presigned/"):
+		body, _ := io.ReadAll(r.Body)
+		s.objects[strings.TrimPrefix(r.URL.Path, "/presigned/")] = string(body)
+		s.uploads++
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/buckets/my-bucket/objects/statistics/"):
+		content, ok := s.objects[strings.TrimPrefix(r.URL.Path, "/api/v1/buckets/my-bucket/objects/statistics/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"object":{"etag":"\"%s\"","metaData":{"content-type":"text/plain"}}}}`, objectETag([]byte(content)))
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v1/buckets/my-bucket/objects/"):
+		delete(s.objects, strings.TrimPrefix(r.URL.Path, "/api/v1/buckets/my-bucket/objects/"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestObjectStorageObjectResource(t *testing.T) {
+	storage := &fakeObjectStorage{objects: map[string]string{}}
+
+	server := httptest.NewServer(storage)
+	defer server.Close()
+
+	client, err := object_storage.NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &ObjectStorageObjectResource{client: client}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	// plan returns the plan of the given content, as modified by the resource.
+	plan := func(t *testing.T, state tfsdk.State, content string) tfsdk.Plan {
+		t.Helper()
+
+		proposed := tfsdk.Plan{
+			Schema: schemaResp.Schema,
+			Raw: testObjectValue(objectType, map[string]tftypes.Value{
+				"bucket":       tftypes.NewValue(tftypes.String, "my-bucket"),
+				"key":          tftypes.NewValue(tftypes.String, "hello.txt"),
+				"content":      tftypes.NewValue(tftypes.String, content),
+				"content_type": tftypes.NewValue(tftypes.String, "text/plain"),
+				"id":           tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"etag":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+		}
+
+		resp := fwresource.ModifyPlanResponse{Plan: proposed}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: proposed, State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		return resp.Plan
+	}
+
+	etag := func(t *testing.T, value tftypes.Value) string {
+		t.Helper()
+
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			t.Fatal(err)
+		}
+
+		var etag string
+		if err := attributes["etag"].As(&etag); err != nil {
+			t.Fatal(err)
+		}
+
+		return etag
+	}
+
+	emptyState := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+
+	// create with inline content
+	createPlan := plan(t, emptyState, "hello")
+	createResp := fwresource.CreateResponse{State: emptyState}
+	r.Create(ctx, fwresource.CreateRequest{Plan: createPlan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	if storage.objects["hello.txt"] != "hello" {
+		t.Fatalf("expected the content to be uploaded, got %q", storage.objects["hello.txt"])
+	}
+
+	if got, want := etag(t, createResp.State.Raw), objectETag([]byte("hello")); got != want {
+		t.Errorf("expected etag %s, got %s", want, got)
+	}
+
+	// an unchanged configuration plans no upload
+	if got, want := etag(t, plan(t, createResp.State, "hello").Raw), etag(t, createResp.State.Raw); got != want {
+		t.Errorf("expected an unchanged etag %s to be planned, got %s", want, got)
+	}
+
+	// a content change is uploaded again
+	updatePlan := plan(t, createResp.State, "hello, world")
+	updateResp := fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: updatePlan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if storage.uploads != 2 || storage.objects["hello.txt"] != "hello, world" {
+		t.Fatalf("expected the new content to be uploaded, got %d uploads and content %q", storage.uploads, storage.objects["hello.txt"])
+	}
+
+	// a change outside of terraform is detected as drift
+	storage.objects["hello.txt"] = "changed remotely"
+
+	readResp := fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+
+	if got, want := etag(t, readResp.State.Raw), objectETag([]byte("changed remotely")); got != want {
+		t.Errorf("expected the remote etag %s to be read, got %s", want, got)
+	}
+
+	if etag(t, plan(t, readResp.State, "hello, world").Raw) == etag(t, readResp.State.Raw) {
+		t.Errorf("expected the drifted object to be planned for upload")
+	}
+
+	// delete
+	deleteResp := fwresource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", deleteResp.Diagnostics)
+	}
+
+	if _, ok := storage.objects["hello.txt"]; ok {
+		t.Errorf("expected the object to be deleted")
+	}
+}
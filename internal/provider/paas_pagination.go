@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+// appListItem is an app, as listed by the API.
+type appListItem struct {
+	ID     string `json:"_id"`
+	Name   string `json:"project_id"`
+	Type   string `json:"type"`
+	PlanID string `json:"planID"`
+	Status string `json:"status"`
+}
+
+// listApps returns all the apps of the account, following the pages of the
+// listing until a page adds no new apps. It returns nil if the apps couldn't
+// be listed.
+func listApps(ctx context.Context, client paas.ClientInterface, diagnostics *diag.Diagnostics) []appListItem {
+	var apps []appListItem
+	seen := make(map[string]bool)
+
+	for page := 1; ; page++ {
+		items := listAppsPage(ctx, client, page, diagnostics)
+		if items == nil {
+			return nil
+		}
+
+		// the page size is up to the API, so a short page doesn't mean it is
+		// the last one. An API that doesn't paginate returns the same apps for
+		// every page, which are only added once.
+		added := 0
+		for _, item := range items {
+			if seen[item.ID] {
+				continue
+			}
+
+			seen[item.ID] = true
+			apps = append(apps, item)
+			added++
+		}
+
+		if added == 0 {
+			return apps
+		}
+	}
+}
+
+// listAppsPage returns a page of the apps, it returns nil if the page
+// couldn't be read.
+func listAppsPage(ctx context.Context, client paas.ClientInterface, page int, diagnostics *diag.Diagnostics) []appListItem {
+	// the listing has no parameters in the API spec, so the page parameter
+	// of the paginated listings, such as the releases, is added to the
+	// generated request. The page size is left to the API.
+	response, err := client.GetApps(ctx, func(ctx context.Context, req *http.Request) error {
+		query := req.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		req.URL.RawQuery = query.Encode()
+
+		return nil
+	})
+	if err != nil {
+		diagnostics.AddError("Listing apps failed", fmt.Sprintf("Unable to list apps, got error: %s", err))
+		return nil
+	}
+	defer closeResponseBody(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			diagnostics.AddError("reading response payload failed", err.Error())
+
+			return nil
+		}
+
+		diagnostics.AddError("Listing apps failed", fmt.Sprintf("Unable to list apps, got error: %s", responseErrorMessage(response, body)))
+		return nil
+	}
+
+	responseModel := struct {
+		Projects []appListItem `json:"projects"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&responseModel); err != nil {
+		diagnostics.AddError("Decoding apps response failed", fmt.Sprintf("Unable to decode apps response, got error: %s", err))
+		return nil
+	}
+
+	if responseModel.Projects == nil {
+		return []appListItem{}
+	}
+
+	return responseModel.Projects
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+// testPaasServer is a fake PaaS API for acceptance tests, so they run
+// against the provider's api_endpoint without reaching Liara. Apps created
+// through it are kept in memory, so they can be read, scaled, resized and
+// deleted again.
+type testPaasServer struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	apps map[string]map[string]any
+}
+
+// newTestPaasServer starts a fake PaaS API, which is closed when the test
+// ends. Requests it has no canned response for fail the test.
+func newTestPaasServer(t *testing.T) *testPaasServer {
+	t.Helper()
+
+	s := &testPaasServer{apps: make(map[string]map[string]any)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		appPath, isApp := strings.CutPrefix(r.URL.Path, "/v1/projects/")
+		name, action, _ := strings.Cut(appPath, "/")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/projects":
+			var body paas.CreateAppJSONRequestBody
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Name == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"invalid app"}`))
+				return
+			}
+
+			s.apps[*body.Name] = map[string]any{
+				"_id":                    "id-" + *body.Name,
+				"project_id":             *body.Name,
+				"type":                   valueOrZero(body.Platform),
+				"planID":                 valueOrZero(body.PlanID),
+				"readOnlyRootFilesystem": valueOrZero(body.ReadOnlyRootFilesystem),
+				"status":                 "RUNNING",
+				"scale":                  1,
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && isApp && action == "actions/scale":
+			var body paas.TurnAppJSONRequestBody
+			app, ok := s.apps[name]
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"invalid scale"}`))
+				return
+			}
+
+			app["scale"] = body.Scale
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && isApp && action == "resize":
+			var body paas.ChangePlanJSONRequestBody
+			app, ok := s.apps[name]
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"invalid plan"}`))
+				return
+			}
+
+			app["planID"] = body.PlanID
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && isApp && action == "":
+			app, ok := s.apps[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"app not found"}`))
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]any{"project": app})
+		case r.Method == http.MethodDelete && isApp && action == "":
+			delete(s.apps, name)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to the fake PaaS API: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotImplemented)
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"message":"%s %s is not faked"}`, r.Method, r.URL.Path)))
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// providerConfig returns the provider configuration pointing at the fake
+// PaaS API.
+func (s *testPaasServer) providerConfig() string {
+	return fmt.Sprintf(`
+provider "liara" {
+  api_endpoint = %q
+  access_token = "token"
+}
+`, s.URL)
+}
+
+// appNames returns the names of the apps on the fake PaaS API.
+func (s *testPaasServer) appNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(s.apps))
+}
+
+// valueOrZero returns the value of the pointer, or the zero value when it
+// is nil.
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+
+	return *value
+}
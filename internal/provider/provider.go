@@ -2,29 +2,93 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/db_inspector"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/dbaas"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/dns"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/file_browser"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/object_storage"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
 )
 
 const (
-	providerName             string = "liara"
-	defaultAPIEndpoint              = "https://api.iran.liara.ir"
-	defaultWebsocketEndpoint        = "wss://api.iran.liara.ir"
-	defaultTimeout           int64  = 30
+	providerName                 string = "liara"
+	defaultAPIEndpoint                  = "https://api.iran.liara.ir"
+	defaultWebsocketEndpoint            = "wss://api.iran.liara.ir"
+	defaultObjectStorageEndpoint        = "https://storage-service.iran.liara.ir"
+	defaultDNSEndpoint                  = "https://dns-service.iran.liara.ir"
+	defaultTimeout               int64  = 30
+	defaultMaxRetries            int64  = 3
+	defaultRetryWaitSeconds      int64  = 1
+
+	// all the requests go to a few Liara hosts, so more idle connections are
+	// kept per host than the 2 of the default transport.
+	defaultMaxIdleConns        int64 = 100
+	defaultMaxIdleConnsPerHost int64 = 16
 )
 
+// headerNamePattern matches the valid HTTP header names, which are tokens as
+// defined by RFC 9110.
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// liaraRegion holds the endpoints of a Liara region.
+type liaraRegion struct {
+	APIEndpoint       string
+	WebsocketEndpoint string
+
+	// AppDomain is the domain the default subdomains of the apps are
+	// served from.
+	AppDomain string
+
+	// ObjectStorageHost is the host of the S3-compatible object storage
+	// endpoint.
+	ObjectStorageHost string
+}
+
+// liaraRegions maps the region names to their endpoints.
+var liaraRegions = map[string]liaraRegion{
+	"iran": {
+		APIEndpoint:       "https://api.iran.liara.ir",
+		WebsocketEndpoint: "wss://api.iran.liara.ir",
+		AppDomain:         "liara.run",
+		ObjectStorageHost: "storage.iran.liara.space",
+	},
+	"germany": {
+		APIEndpoint:       "https://api.liara.ir",
+		WebsocketEndpoint: "wss://api.liara.ir",
+		AppDomain:         "liara.run",
+		ObjectStorageHost: "storage.liara.space",
+	},
+}
+
+// liaraRegionNames lists the names of the regions, sorted.
+var liaraRegionNames = slices.Sorted(maps.Keys(liaraRegions))
+
 // Ensure LiaraProvider satisfies various provider interfaces.
 var _ provider.Provider = &LiaraProvider{}
 var _ provider.ProviderWithFunctions = &LiaraProvider{}
@@ -36,23 +100,65 @@ type LiaraProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// clock is the clock of the retry and polling loops, the system clock
+	// when nil. Tests replace it so they don't wait in real time.
+	clock clock
 }
 
 // LiaraClient keeps the client configuration for data sources and resources.
 type LiaraProviderData struct {
-	APIEndpoint       string
-	WebsocketEndpoint string
-	AccessToken       string
-	Timeout           time.Duration
-	HTTPClient        *http.Client
+	APIEndpoint           string
+	WebsocketEndpoint     string
+	ObjectStorageEndpoint string
+	DNSEndpoint           string
+	FileBrowserEndpoint   string
+	DBInspectorEndpoint   string
+	AccessToken           string
+	UserAgent             string
+	DefaultHeaders        map[string]string
+	Timeout               time.Duration
+	HTTPClient            *http.Client
+	Clock                 clock
+
+	// Transport is the base transport of HTTPClient, with the TLS
+	// configuration and the proxy of the provider. Connections which aren't
+	// plain HTTP requests, like the logs websocket, are dialed with it.
+	Transport *http.Transport
+
+	// the API clients are created once and shared by all the data sources
+	// and resources. The file browser and the database inspector clients
+	// are nil when their endpoints aren't set.
+	PaasClient          paas.ClientInterface
+	DBaaSClient         dbaas.ClientInterface
+	DNSClient           dns.ClientInterface
+	ObjectStorageClient object_storage.ClientInterface
+	FileBrowserClient   file_browser.ClientInterface
+	DBInspectorClient   db_inspector.ClientInterface
 }
 
 // LiaraProviderModel describes the provider data model.
 type LiaraProviderModel struct {
-	APIEndpoint       types.String `tfsdk:"api_endpoint"`
-	WebsocketEndpoint types.String `tfsdk:"websocket_endpoint"`
-	AccessToken       types.String `tfsdk:"access_token"`
-	Timeout           types.Int64  `tfsdk:"timeout"`
+	Region                types.String `tfsdk:"region"`
+	APIEndpoint           types.String `tfsdk:"api_endpoint"`
+	WebsocketEndpoint     types.String `tfsdk:"websocket_endpoint"`
+	BasePath              types.String `tfsdk:"base_path"`
+	ObjectStorageEndpoint types.String `tfsdk:"object_storage_endpoint"`
+	DNSEndpoint           types.String `tfsdk:"dns_endpoint"`
+	FileBrowserEndpoint   types.String `tfsdk:"file_browser_endpoint"`
+	DBInspectorEndpoint   types.String `tfsdk:"db_inspector_endpoint"`
+	AccessToken           types.String `tfsdk:"access_token"`
+	AccessTokenFile       types.String `tfsdk:"access_token_file"`
+	Timeout               types.Int64  `tfsdk:"timeout"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryWaitSeconds      types.Int64  `tfsdk:"retry_wait_seconds"`
+	MaxIdleConns          types.Int64  `tfsdk:"max_idle_conns"`
+	MaxIdleConnsPerHost   types.Int64  `tfsdk:"max_idle_conns_per_host"`
+	CACertFile            types.String `tfsdk:"ca_cert_file"`
+	InsecureSkipVerify    types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL              types.String `tfsdk:"proxy_url"`
+	UserAgentSuffix       types.String `tfsdk:"user_agent_suffix"`
+	DefaultHeaders        types.Map    `tfsdk:"default_headers"`
 }
 
 func (p *LiaraProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -63,23 +169,97 @@ func (p *LiaraProvider) Metadata(ctx context.Context, req provider.MetadataReque
 func (p *LiaraProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Liara region, one of %s. Selects the default `api_endpoint` and `websocket_endpoint`, explicitly set endpoints take precedence (default: iran)", strings.Join(liaraRegionNames, ", ")),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(liaraRegionNames...),
+				},
+			},
 			"api_endpoint": schema.StringAttribute{
-				MarkdownDescription: "Liara API endpoint",
+				MarkdownDescription: "Liara API endpoint, takes precedence over `region`",
 				Optional:            true,
 			},
 			"websocket_endpoint": schema.StringAttribute{
-				MarkdownDescription: "Liara Websocket endpoint",
+				MarkdownDescription: "Liara Websocket endpoint, takes precedence over `region`",
+				Optional:            true,
+			},
+			"base_path": schema.StringAttribute{
+				MarkdownDescription: "path prefix the Liara API is mounted under, e.g. `/api/v1` for self-hosted deployments. Only prepended to the paths of the `api_endpoint` and `websocket_endpoint` requests, the `object_storage_endpoint`, `dns_endpoint`, `file_browser_endpoint` and `db_inspector_endpoint` are used as they are",
+				Optional:            true,
+			},
+			"object_storage_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Liara object storage API endpoint",
+				Optional:            true,
+			},
+			"dns_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Liara DNS API endpoint",
+				Optional:            true,
+			},
+			"file_browser_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Liara file browser API endpoint, required by `liara_file_browser_upload`",
+				Optional:            true,
+			},
+			"db_inspector_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Liara database inspector API endpoint, required by `liara_db_query`",
 				Optional:            true,
 			},
 			"access_token": schema.StringAttribute{
-				MarkdownDescription: "Liara access token",
-				Required:            true,
+				MarkdownDescription: "Liara access token, takes precedence over `access_token_file`",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"access_token_file": schema.StringAttribute{
+				MarkdownDescription: "path of a file containing the Liara access token, takes precedence over the LIARA_ACCESS_TOKEN environment variable",
+				Optional:            true,
+			},
 			"timeout": schema.Int64Attribute{
-				MarkdownDescription: "Liara API timeout in seconds (default: 30)",
+				MarkdownDescription: "Liara API timeout in seconds, applies to each operation as a whole (default: 30)",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "maximum number of retries of idempotent requests failed with a transient error (429 or 5xx), 0 disables retries (default: 3)",
+				Optional:            true,
+			},
+			"retry_wait_seconds": schema.Int64Attribute{
+				MarkdownDescription: "initial wait in seconds between retries, doubled on each retry unless the API sends a Retry-After header, and capped at 60 seconds or half the timeout, whichever is lower (default: 1)",
 				Optional:            true,
 			},
+			"max_idle_conns": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("maximum number of idle connections kept open to all the API endpoints, 0 means no limit (default: %d)", defaultMaxIdleConns),
+				Optional:            true,
+			},
+			"max_idle_conns_per_host": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("maximum number of idle connections kept open to each API endpoint (default: %d)", defaultMaxIdleConnsPerHost),
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "path of a PEM file with additional CA certificates to trust, e.g. for self-hosted or staging endpoints",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "skip verifying the TLS certificates of the API endpoints, only meant for testing (default: false)",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy to send the API requests through, with an `http`, `https` or `socks5` scheme. The HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables are used when unset",
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "text appended to the User-Agent header of the API requests, e.g. to identify the CI environment",
+				Optional:            true,
+			},
+			"default_headers": schema.MapAttribute{
+				MarkdownDescription: "headers added to the API requests, e.g. for a gateway that requires an `X-Org-Id` header. The Authorization and User-Agent headers are set by the provider and can't be overridden",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(headerNamePattern, "must be a valid HTTP header name"),
+						stringvalidator.NoneOfCaseInsensitive("Authorization", "User-Agent"),
+					),
+				},
+			},
 		},
 	}
 }
@@ -113,6 +293,60 @@ func (p *LiaraProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	if data.BasePath.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_path"),
+			"Unknown Liara API Base Path",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara API base path. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_BASE_PATH environment variable.",
+		)
+	}
+
+	if data.ObjectStorageEndpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("object_storage_endpoint"),
+			"Unknown Liara Object Storage Endpoint",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara object storage endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_OBJECT_STORAGE_ENDPOINT environment variable.",
+		)
+	}
+
+	if data.DNSEndpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dns_endpoint"),
+			"Unknown Liara DNS Endpoint",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara DNS endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_DNS_ENDPOINT environment variable.",
+		)
+	}
+
+	if data.Region.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Unknown Liara Region",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara region. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_REGION environment variable.",
+		)
+	}
+
+	if data.FileBrowserEndpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("file_browser_endpoint"),
+			"Unknown Liara File Browser Endpoint",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara file browser endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_FILE_BROWSER_ENDPOINT environment variable.",
+		)
+	}
+
+	if data.DBInspectorEndpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("db_inspector_endpoint"),
+			"Unknown Liara Database Inspector Endpoint",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara database inspector endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_DB_INSPECTOR_ENDPOINT environment variable.",
+		)
+	}
+
 	if data.AccessToken.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("access_token"),
@@ -122,6 +356,15 @@ func (p *LiaraProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	if data.AccessTokenFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("access_token_file"),
+			"Unknown Liara Access Token File",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara Access Token file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_ACCESS_TOKEN_FILE environment variable.",
+		)
+	}
+
 	if data.Timeout.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("timeout"),
@@ -131,17 +374,148 @@ func (p *LiaraProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	if data.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown Liara Max Retries",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara Max Retries. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_MAX_RETRIES environment variable.",
+		)
+	}
+
+	if data.RetryWaitSeconds.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_seconds"),
+			"Unknown Liara Retry Wait",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara Retry Wait. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_RETRY_WAIT_SECONDS environment variable.",
+		)
+	}
+
+	if data.MaxIdleConns.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_conns"),
+			"Unknown Liara Max Idle Connections",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara max idle connections. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_MAX_IDLE_CONNS environment variable.",
+		)
+	}
+
+	if data.MaxIdleConnsPerHost.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_conns_per_host"),
+			"Unknown Liara Max Idle Connections Per Host",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara max idle connections per host. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_MAX_IDLE_CONNS_PER_HOST environment variable.",
+		)
+	}
+
+	if data.CACertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unknown Liara CA Certificate File",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara CA certificate file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_CA_CERT_FILE environment variable.",
+		)
+	}
+
+	if data.InsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Unknown Liara Insecure Skip Verify",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara insecure skip verify option. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_INSECURE_SKIP_VERIFY environment variable.",
+		)
+	}
+
+	if data.ProxyURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown Liara Proxy URL",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara proxy URL. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the HTTPS_PROXY or HTTP_PROXY environment variable.",
+		)
+	}
+
+	if data.DefaultHeaders.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_headers"),
+			"Unknown Liara Default Headers",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the default headers. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if data.UserAgentSuffix.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_agent_suffix"),
+			"Unknown Liara User Agent Suffix",
+			"The provider cannot create the Liara API client as there is an unknown configuration value for the Liara User-Agent suffix. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LIARA_USER_AGENT_SUFFIX environment variable.",
+		)
+	}
+
 	// 1. load defaults
 	apiEndpoint := defaultAPIEndpoint
 	websocketEndpoint := defaultWebsocketEndpoint
+	basePath := ""
+	objectStorageEndpoint := defaultObjectStorageEndpoint
+	dnsEndpoint := defaultDNSEndpoint
+	fileBrowserEndpoint := ""
+	dbInspectorEndpoint := ""
 	timeout := defaultTimeout
+	maxRetries := defaultMaxRetries
+	retryWaitSeconds := defaultRetryWaitSeconds
+	maxIdleConns := defaultMaxIdleConns
+	maxIdleConnsPerHost := defaultMaxIdleConnsPerHost
 	accessToken := ""
+	accessTokenFile := ""
+	caCertFile := ""
+	insecureSkipVerify := false
+	userAgentSuffix := ""
+	defaultHeaders := map[string]string{}
+
+	// the endpoints of the region replace the defaults, so endpoints set
+	// with environment variables or in the configuration take precedence.
+	region := os.Getenv("LIARA_REGION")
+	if !data.Region.IsNull() {
+		region = data.Region.ValueString()
+	}
+
+	if len(region) > 0 {
+		preset, ok := liaraRegions[region]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("region"),
+				"Invalid Liara Region",
+				fmt.Sprintf("The Liara region must be one of %s, got: %s", strings.Join(liaraRegionNames, ", "), region),
+			)
+
+			return
+		}
+
+		apiEndpoint = preset.APIEndpoint
+		websocketEndpoint = preset.WebsocketEndpoint
+	}
 
 	// 2. override with ENV variables if set
 	env_apiEndpoint := os.Getenv("LIARA_API_ENDPOINT")
 	env_websocketEndpoint := os.Getenv("LIARA_WEBSOCKET_ENDPOINT")
+	env_basePath := os.Getenv("LIARA_BASE_PATH")
+	env_objectStorageEndpoint := os.Getenv("LIARA_OBJECT_STORAGE_ENDPOINT")
+	env_dnsEndpoint := os.Getenv("LIARA_DNS_ENDPOINT")
+	env_fileBrowserEndpoint := os.Getenv("LIARA_FILE_BROWSER_ENDPOINT")
+	env_dbInspectorEndpoint := os.Getenv("LIARA_DB_INSPECTOR_ENDPOINT")
 	env_timeout := os.Getenv("LIARA_TIMEOUT")
 	env_accessToken := os.Getenv("LIARA_ACCESS_TOKEN")
+	env_accessTokenFile := os.Getenv("LIARA_ACCESS_TOKEN_FILE")
+	env_maxRetries := os.Getenv("LIARA_MAX_RETRIES")
+	env_retryWaitSeconds := os.Getenv("LIARA_RETRY_WAIT_SECONDS")
+	env_maxIdleConns := os.Getenv("LIARA_MAX_IDLE_CONNS")
+	env_maxIdleConnsPerHost := os.Getenv("LIARA_MAX_IDLE_CONNS_PER_HOST")
+	env_caCertFile := os.Getenv("LIARA_CA_CERT_FILE")
+	env_insecureSkipVerify := os.Getenv("LIARA_INSECURE_SKIP_VERIFY")
+	env_userAgentSuffix := os.Getenv("LIARA_USER_AGENT_SUFFIX")
 
 	if len(env_apiEndpoint) > 0 {
 		apiEndpoint = env_apiEndpoint
@@ -151,6 +525,26 @@ func (p *LiaraProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		websocketEndpoint = env_websocketEndpoint
 	}
 
+	if len(env_basePath) > 0 {
+		basePath = env_basePath
+	}
+
+	if len(env_objectStorageEndpoint) > 0 {
+		objectStorageEndpoint = env_objectStorageEndpoint
+	}
+
+	if len(env_dnsEndpoint) > 0 {
+		dnsEndpoint = env_dnsEndpoint
+	}
+
+	if len(env_fileBrowserEndpoint) > 0 {
+		fileBrowserEndpoint = env_fileBrowserEndpoint
+	}
+
+	if len(env_dbInspectorEndpoint) > 0 {
+		dbInspectorEndpoint = env_dbInspectorEndpoint
+	}
+
 	if len(env_timeout) > 0 {
 		timeoutInt, err := strconv.ParseInt(env_timeout, 10, 64)
 		if err != nil {
@@ -164,6 +558,63 @@ func (p *LiaraProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		accessToken = env_accessToken
 	}
 
+	if len(env_accessTokenFile) > 0 {
+		accessTokenFile = env_accessTokenFile
+	}
+
+	if len(env_maxRetries) > 0 {
+		maxRetriesInt, err := strconv.ParseInt(env_maxRetries, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid max retries value", fmt.Sprintf("Invalid max retries value: %s", err))
+			return
+		}
+		maxRetries = maxRetriesInt
+	}
+
+	if len(env_retryWaitSeconds) > 0 {
+		retryWaitSecondsInt, err := strconv.ParseInt(env_retryWaitSeconds, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid retry wait value", fmt.Sprintf("Invalid retry wait value: %s", err))
+			return
+		}
+		retryWaitSeconds = retryWaitSecondsInt
+	}
+
+	if len(env_maxIdleConns) > 0 {
+		maxIdleConnsInt, err := strconv.ParseInt(env_maxIdleConns, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid max idle connections value", fmt.Sprintf("Invalid max idle connections value: %s", err))
+			return
+		}
+		maxIdleConns = maxIdleConnsInt
+	}
+
+	if len(env_maxIdleConnsPerHost) > 0 {
+		maxIdleConnsPerHostInt, err := strconv.ParseInt(env_maxIdleConnsPerHost, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid max idle connections per host value", fmt.Sprintf("Invalid max idle connections per host value: %s", err))
+			return
+		}
+		maxIdleConnsPerHost = maxIdleConnsPerHostInt
+	}
+
+	if len(env_userAgentSuffix) > 0 {
+		userAgentSuffix = env_userAgentSuffix
+	}
+
+	if len(env_caCertFile) > 0 {
+		caCertFile = env_caCertFile
+	}
+
+	if len(env_insecureSkipVerify) > 0 {
+		insecureSkipVerifyBool, err := strconv.ParseBool(env_insecureSkipVerify)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid insecure skip verify value", fmt.Sprintf("Invalid insecure skip verify value: %s", err))
+			return
+		}
+		insecureSkipVerify = insecureSkipVerifyBool
+	}
+
 	// 3. override with Terraform configs if set
 	if !data.APIEndpoint.IsNull() {
 		apiEndpoint = data.APIEndpoint.ValueString()
@@ -173,12 +624,92 @@ func (p *LiaraProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		websocketEndpoint = data.WebsocketEndpoint.ValueString()
 	}
 
+	if !data.BasePath.IsNull() {
+		basePath = data.BasePath.ValueString()
+	}
+
+	if !data.ObjectStorageEndpoint.IsNull() {
+		objectStorageEndpoint = data.ObjectStorageEndpoint.ValueString()
+	}
+
+	if !data.DNSEndpoint.IsNull() {
+		dnsEndpoint = data.DNSEndpoint.ValueString()
+	}
+
+	if !data.FileBrowserEndpoint.IsNull() {
+		fileBrowserEndpoint = data.FileBrowserEndpoint.ValueString()
+	}
+
+	if !data.DBInspectorEndpoint.IsNull() {
+		dbInspectorEndpoint = data.DBInspectorEndpoint.ValueString()
+	}
+
 	if !data.Timeout.IsNull() {
 		timeout = data.Timeout.ValueInt64()
 	}
 
+	if !data.AccessTokenFile.IsNull() {
+		accessTokenFile = data.AccessTokenFile.ValueString()
+	}
+
+	// an explicit access token wins over the token file, which wins over
+	// the LIARA_ACCESS_TOKEN environment variable.
 	if !data.AccessToken.IsNull() {
 		accessToken = data.AccessToken.ValueString()
+	} else if len(accessTokenFile) > 0 {
+		content, err := os.ReadFile(accessTokenFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("access_token_file"),
+				"Unreadable Liara Access Token File",
+				fmt.Sprintf("The provider cannot read the Liara access token from %s, got error: %s", accessTokenFile, err),
+			)
+
+			return
+		}
+
+		accessToken = strings.TrimSpace(string(content))
+		if len(accessToken) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("access_token_file"),
+				"Empty Liara Access Token File",
+				fmt.Sprintf("The Liara access token file %s is empty.", accessTokenFile),
+			)
+
+			return
+		}
+	}
+
+	if !data.MaxRetries.IsNull() {
+		maxRetries = data.MaxRetries.ValueInt64()
+	}
+
+	if !data.RetryWaitSeconds.IsNull() {
+		retryWaitSeconds = data.RetryWaitSeconds.ValueInt64()
+	}
+
+	if !data.MaxIdleConns.IsNull() {
+		maxIdleConns = data.MaxIdleConns.ValueInt64()
+	}
+
+	if !data.MaxIdleConnsPerHost.IsNull() {
+		maxIdleConnsPerHost = data.MaxIdleConnsPerHost.ValueInt64()
+	}
+
+	if !data.CACertFile.IsNull() {
+		caCertFile = data.CACertFile.ValueString()
+	}
+
+	if !data.UserAgentSuffix.IsNull() {
+		userAgentSuffix = data.UserAgentSuffix.ValueString()
+	}
+
+	if !data.DefaultHeaders.IsNull() {
+		resp.Diagnostics.Append(data.DefaultHeaders.ElementsAs(ctx, &defaultHeaders, false)...)
+	}
+
+	if !data.InsecureSkipVerify.IsNull() {
+		insecureSkipVerify = data.InsecureSkipVerify.ValueBool()
 	}
 
 	// If any of the expected configurations are missing, return
@@ -194,12 +725,33 @@ func (p *LiaraProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
-	if len(websocketEndpoint) == 0 {
+	// an empty websocket endpoint is only rejected by the data sources and
+	// resources which use the websocket, the others don't need it.
+
+	if len(basePath) > 0 && !strings.HasPrefix(basePath, "/") {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("websocket_endpoint"),
-			"Missing Liara Websocket Endpoint",
-			"The provider cannot create the Liara API client as there is a missing or empty value for the Liara Websocket endpoint. "+
-				"Set the websocket_endpoint value in the configuration or use the LIARA_WEBSOCKET_ENDPOINT environment variable. "+
+			path.Root("base_path"),
+			"Invalid Liara API Base Path",
+			fmt.Sprintf("base_path must start with a /, got: %s", basePath),
+		)
+	}
+
+	if len(objectStorageEndpoint) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("object_storage_endpoint"),
+			"Missing Liara Object Storage Endpoint",
+			"The provider cannot create the Liara API client as there is a missing or empty value for the Liara object storage endpoint. "+
+				"Set the object_storage_endpoint value in the configuration or use the LIARA_OBJECT_STORAGE_ENDPOINT environment variable. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+	}
+
+	if len(dnsEndpoint) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dns_endpoint"),
+			"Missing Liara DNS Endpoint",
+			"The provider cannot create the Liara API client as there is a missing or empty value for the Liara DNS endpoint. "+
+				"Set the dns_endpoint value in the configuration or use the LIARA_DNS_ENDPOINT environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -209,50 +761,340 @@ func (p *LiaraProvider) Configure(ctx context.Context, req provider.ConfigureReq
 			path.Root("access_token"),
 			"Missing Liara Access Token",
 			"The provider cannot create the Liara API client as there is a missing or empty value for the Liara Access Token. "+
-				"Set the access_token value in the configuration or use the LIARA_ACCESS_TOKEN environment variable. "+
+				"Set the access_token or access_token_file value in the configuration or use the LIARA_ACCESS_TOKEN or LIARA_ACCESS_TOKEN_FILE environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Liara Max Retries",
+			fmt.Sprintf("max_retries must not be negative, got: %d", maxRetries),
+		)
+	}
+
+	if retryWaitSeconds < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_seconds"),
+			"Invalid Liara Retry Wait",
+			fmt.Sprintf("retry_wait_seconds must not be negative, got: %d", retryWaitSeconds),
+		)
+	}
+
+	if maxIdleConns < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_conns"),
+			"Invalid Liara Max Idle Connections",
+			fmt.Sprintf("max_idle_conns must not be negative, got: %d", maxIdleConns),
+		)
+	}
+
+	if maxIdleConnsPerHost < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_conns_per_host"),
+			"Invalid Liara Max Idle Connections Per Host",
+			fmt.Sprintf("max_idle_conns_per_host must be a positive number, got: %d", maxIdleConnsPerHost),
+		)
+	}
+
+	var proxyURL *url.URL
+	if !data.ProxyURL.IsNull() {
+		parsedProxyURL, err := parseProxyURL(data.ProxyURL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Liara Proxy URL",
+				fmt.Sprintf("proxy_url must be a URL with an http, https or socks5 scheme, got error: %s", err),
+			)
+		}
+		proxyURL = parsedProxyURL
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	tlsConfig, err := newTLSConfig(caCertFile, insecureSkipVerify)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Invalid Liara CA Certificate File",
+			fmt.Sprintf("The provider cannot load the CA certificates from %s, got error: %s", caCertFile, err),
+		)
+
+		return
+	}
+
+	if insecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"Insecure Liara API Connection",
+			"TLS certificate verification is disabled, so the connection to the Liara API can be intercepted. "+
+				"Only use insecure_skip_verify for testing, and prefer ca_cert_file to trust custom certificates.",
+		)
+	}
+
+	baseTransport := &http.Transport{}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		baseTransport = defaultTransport.Clone()
+	}
+	baseTransport.TLSClientConfig = tlsConfig
+	baseTransport.MaxIdleConns = int(maxIdleConns)
+	baseTransport.MaxIdleConnsPerHost = int(maxIdleConnsPerHost)
+
+	// the default transport already honours the proxy environment variables.
+	if proxyURL != nil {
+		baseTransport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	// requests are only logged at debug level, each attempt of a retried
+	// request is logged separately.
+	var transport http.RoundTripper = baseTransport
+	if debugLoggingEnabled() {
+		transport = newLoggingTransport(transport)
+	}
+
+	providerClock := p.clock
+	if providerClock == nil {
+		providerClock = realClock{}
+	}
+
 	// client configuration for data sources and resources
 	providerData := &LiaraProviderData{
-		APIEndpoint:       apiEndpoint,
-		WebsocketEndpoint: websocketEndpoint,
-		AccessToken:       accessToken,
-		HTTPClient:        &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		APIEndpoint:           withBasePath(apiEndpoint, basePath),
+		WebsocketEndpoint:     withBasePath(websocketEndpoint, basePath),
+		ObjectStorageEndpoint: objectStorageEndpoint,
+		DNSEndpoint:           dnsEndpoint,
+		FileBrowserEndpoint:   fileBrowserEndpoint,
+		DBInspectorEndpoint:   dbInspectorEndpoint,
+		AccessToken:           accessToken,
+		UserAgent:             userAgent(p.version, userAgentSuffix),
+		DefaultHeaders:        defaultHeaders,
+		Timeout:               time.Duration(timeout) * time.Second,
+		HTTPClient: &http.Client{
+			Timeout:   time.Duration(timeout) * time.Second,
+			Transport: newRetryTransport(transport, maxRetries, time.Duration(retryWaitSeconds)*time.Second, time.Duration(timeout)*time.Second, providerClock),
+		},
+		Clock:     providerClock,
+		Transport: baseTransport,
+	}
+
+	if err := providerData.createClients(); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create API clients",
+			fmt.Sprintf("Unable to create the Liara API clients, got error: %s. Please report this issue to the provider developers.", err),
+		)
+
+		return
 	}
+
 	resp.DataSourceData = providerData
 	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+}
+
+// createClients creates the API clients of the provider. The clients only
+// hold the configuration and the shared HTTP client, so they are safe for
+// concurrent use by the data sources and resources.
+func (d *LiaraProviderData) createClients() error {
+	var err error
+
+	if d.PaasClient, err = paas.NewClient(d.APIEndpoint, paas.WithHTTPClient(d.HTTPClient), paas.WithRequestEditorFn(d.authorize)); err != nil {
+		return fmt.Errorf("PAAS client: %w", err)
+	}
+
+	if d.DBaaSClient, err = dbaas.NewClient(d.APIEndpoint, dbaas.WithHTTPClient(d.HTTPClient), dbaas.WithRequestEditorFn(d.authorize)); err != nil {
+		return fmt.Errorf("DBaaS client: %w", err)
+	}
+
+	if d.DNSClient, err = dns.NewClient(d.DNSEndpoint, dns.WithHTTPClient(d.HTTPClient), dns.WithRequestEditorFn(d.authorize)); err != nil {
+		return fmt.Errorf("DNS client: %w", err)
+	}
+
+	if d.ObjectStorageClient, err = object_storage.NewClient(d.ObjectStorageEndpoint, object_storage.WithHTTPClient(d.HTTPClient), object_storage.WithRequestEditorFn(d.authorize)); err != nil {
+		return fmt.Errorf("object storage client: %w", err)
+	}
+
+	if len(d.FileBrowserEndpoint) > 0 {
+		if d.FileBrowserClient, err = file_browser.NewClient(d.FileBrowserEndpoint, file_browser.WithHTTPClient(d.HTTPClient), file_browser.WithRequestEditorFn(d.authorize)); err != nil {
+			return fmt.Errorf("file browser client: %w", err)
+		}
+	}
+
+	if len(d.DBInspectorEndpoint) > 0 {
+		if d.DBInspectorClient, err = db_inspector.NewClient(d.DBInspectorEndpoint, db_inspector.WithHTTPClient(d.HTTPClient), db_inspector.WithRequestEditorFn(d.authorize)); err != nil {
+			return fmt.Errorf("database inspector client: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// authorize sets the default headers, the access token and the user agent
+// on the requests of the API clients. The default headers are set first, so
+// they never override the others.
+func (d *LiaraProviderData) authorize(ctx context.Context, req *http.Request) error {
+	for name, value := range d.DefaultHeaders {
+		req.Header.Set(name, value)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", d.AccessToken))
+	req.Header.Set("User-Agent", d.UserAgent)
+
+	return nil
+}
+
+// withBasePath returns the endpoint with the base path appended, so the
+// request paths of the clients are prefixed with it.
+func withBasePath(endpoint string, basePath string) string {
+	basePath = strings.TrimRight(basePath, "/")
+	if len(endpoint) == 0 || len(basePath) == 0 {
+		return endpoint
+	}
+
+	return strings.TrimRight(endpoint, "/") + basePath
+}
+
+// userAgent returns the User-Agent header of the API requests, which
+// identifies the provider and its version to Liara.
+func userAgent(version string, suffix string) string {
+	agent := fmt.Sprintf("terraform-provider-%s/%s (terraform-plugin-framework)", providerName, version)
+	if suffix = strings.TrimSpace(suffix); len(suffix) > 0 {
+		agent += " " + suffix
+	}
+
+	return agent
+}
+
+// newTLSConfig returns the TLS configuration of the API connections, trusting
+// the certificates in caCertFile on top of the system ones.
+func newTLSConfig(caCertFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	if len(caCertFile) == 0 {
+		return config, nil
+	}
+
+	pem, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no PEM encoded certificates found")
+	}
+
+	config.RootCAs = pool
+
+	return config, nil
+}
+
+// parseProxyURL parses and validates the URL of a proxy.
+func parseProxyURL(rawURL string) (*url.URL, error) {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", proxyURL.Scheme)
+	}
+
+	if len(proxyURL.Host) == 0 {
+		return nil, errors.New("missing host")
+	}
+
+	return proxyURL, nil
 }
 
 func (p *LiaraProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAppResource,
+		NewAppDeploymentResource,
+		NewObjectStorageObjectResource,
+		NewDBBackupResource,
+		NewFileBrowserUploadResource,
 	}
 }
 
 func (p *LiaraProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
 	return []func() ephemeral.EphemeralResource{
-		// no ephemeral resources, at least for now!
+		NewDatabaseCredentialsEphemeralResource,
 	}
 }
 
 func (p *LiaraProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAppDataSource,
+		NewAPIStatusDataSource,
+		NewAppDeploymentsDataSource,
+		NewAppDiskUsageDataSource,
+		NewAppsDataSource,
+		NewDNSZoneDataSource,
+		NewAppLogsDataSource,
+		NewDBQueryDataSource,
 	}
 }
 
 func (p *LiaraProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		// no functions, at least for now!
+		NewAppConfigJSONFunction,
+		NewAppURLFunction,
+		NewObjectStorageEndpointFunction,
+		NewParseDBURLFunction,
+		NewBuildDSNFunction,
+		NewDotenvFunction,
 	}
 }
 
+// withOperationTimeout bounds a logical operation, which may consist of
+// several API calls, by the provider timeout. The returned function releases
+// the context and, if the operation failed because it ran out of time or was
+// cancelled, reports why.
+func withOperationTimeout(ctx context.Context, timeout time.Duration, diagnostics *diag.Diagnostics) (context.Context, func()) {
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	return ctx, func() {
+		// an operation which completed right before the deadline succeeded,
+		// even if the context is done by now.
+		switch {
+		case !diagnostics.HasError():
+		case timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded):
+			diagnostics.AddError(
+				"Operation timed out",
+				fmt.Sprintf("The operation did not complete within %s. Increase the provider timeout, or the timeouts block of the resource where supported, if the Liara API is slow to respond.", timeout),
+			)
+		case errors.Is(ctx.Err(), context.Canceled):
+			addOperationCancelledError(diagnostics)
+		}
+
+		cancel()
+	}
+}
+
+// addOperationCancelledError reports that Terraform cancelled the operation,
+// e.g. on an interrupt, before it completed.
+func addOperationCancelledError(diagnostics *diag.Diagnostics) {
+	diagnostics.AddError(
+		"Operation cancelled",
+		"The operation was cancelled before it completed. The resources on Liara may be partially changed, run Terraform again to reconcile them.",
+	)
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &LiaraProvider{
@@ -1,10 +1,30 @@
 package provider
 
 import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 )
 
@@ -31,3 +51,715 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestProviderConfigureAccessToken(t *testing.T) {
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name        string
+		env         map[string]string
+		attributes  map[string]tftypes.Value
+		expectToken string
+		expectError string
+	}{
+		{
+			name:        "token from the environment",
+			env:         map[string]string{"LIARA_ACCESS_TOKEN": "env-token"},
+			expectToken: "env-token",
+		},
+		{
+			name: "token file wins over the environment",
+			env:  map[string]string{"LIARA_ACCESS_TOKEN": "env-token"},
+			attributes: map[string]tftypes.Value{
+				"access_token_file": tftypes.NewValue(tftypes.String, tokenFile),
+			},
+			expectToken: "file-token",
+		},
+		{
+			name:        "token file from the environment",
+			env:         map[string]string{"LIARA_ACCESS_TOKEN": "env-token", "LIARA_ACCESS_TOKEN_FILE": tokenFile},
+			expectToken: "file-token",
+		},
+		{
+			name: "access token wins over the token file",
+			attributes: map[string]tftypes.Value{
+				"access_token":      tftypes.NewValue(tftypes.String, "config-token"),
+				"access_token_file": tftypes.NewValue(tftypes.String, filepath.Join(dir, "missing")),
+			},
+			expectToken: "config-token",
+		},
+		{
+			name: "unreadable token file",
+			attributes: map[string]tftypes.Value{
+				"access_token_file": tftypes.NewValue(tftypes.String, filepath.Join(dir, "missing")),
+			},
+			expectError: "Unreadable Liara Access Token File",
+		},
+		{
+			name: "empty token file",
+			attributes: map[string]tftypes.Value{
+				"access_token_file": tftypes.NewValue(tftypes.String, emptyFile),
+			},
+			expectError: "Empty Liara Access Token File",
+		},
+		{
+			name:        "no token",
+			expectError: "Missing Liara Access Token",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"LIARA_ACCESS_TOKEN", "LIARA_ACCESS_TOKEN_FILE"} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			providerData, diags := testProviderConfigure(t, tc.attributes)
+
+			if len(tc.expectError) > 0 {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tc.expectError {
+					t.Fatalf("expected error %q, got diagnostics: %v", tc.expectError, diags)
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if providerData.AccessToken != tc.expectToken {
+				t.Errorf("expected access token %q, got %q", tc.expectToken, providerData.AccessToken)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCertFile, caCert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		attributes    map[string]tftypes.Value
+		expectWarning bool
+		expectError   bool
+	}{
+		{
+			name:        "untrusted certificate",
+			expectError: true,
+		},
+		{
+			name: "custom CA",
+			attributes: map[string]tftypes.Value{
+				"ca_cert_file": tftypes.NewValue(tftypes.String, caCertFile),
+			},
+		},
+		{
+			name: "insecure skip verify",
+			attributes: map[string]tftypes.Value{
+				"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expectWarning: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes := map[string]tftypes.Value{
+				"access_token": tftypes.NewValue(tftypes.String, "token"),
+			}
+			for key, value := range tc.attributes {
+				attributes[key] = value
+			}
+
+			providerData, diags := testProviderConfigure(t, attributes)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if warned := diags.WarningsCount() > 0; warned != tc.expectWarning {
+				t.Errorf("expected warning %t, got diagnostics: %v", tc.expectWarning, diags)
+			}
+
+			response, err := providerData.HTTPClient.Get(server.URL)
+			if err == nil {
+				response.Body.Close()
+			}
+
+			if failed := err != nil; failed != tc.expectError {
+				t.Errorf("expected request error %t, got: %v", tc.expectError, err)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureInvalidCACertFile(t *testing.T) {
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, "token"),
+		"ca_cert_file": tftypes.NewValue(tftypes.String, caCertFile),
+	})
+
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Liara CA Certificate File" {
+		t.Errorf("expected an invalid CA certificate diagnostic, got: %v", diags)
+	}
+}
+
+func TestProviderConfigureProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a proxy receives the absolute URL of the request.
+		proxiedHost = r.URL.Host
+	}))
+	defer proxy.Close()
+
+	providerData, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, "token"),
+		"proxy_url":    tftypes.NewValue(tftypes.String, proxy.URL),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	response, err := providerData.HTTPClient.Get("http://api.liara.invalid/v1/projects")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	response.Body.Close()
+
+	if proxiedHost != "api.liara.invalid" {
+		t.Errorf("expected the request to be sent through the proxy, got host %q", proxiedHost)
+	}
+
+	// the logs websocket is dialed through the same proxy.
+	proxyURL, err := providerData.Transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "api.liara.invalid"}})
+	if err != nil || proxyURL == nil || proxyURL.String() != proxy.URL {
+		t.Errorf("expected the transport to use the proxy %s, got %v (error: %v)", proxy.URL, proxyURL, err)
+	}
+}
+
+func TestProviderConfigureInvalidProxyURL(t *testing.T) {
+	testCases := []string{
+		"ftp://proxy.example.com",
+		"proxy.example.com:8080",
+		"http://",
+		"http://[::1",
+	}
+
+	for _, proxyURL := range testCases {
+		t.Run(proxyURL, func(t *testing.T) {
+			_, diags := testProviderConfigure(t, map[string]tftypes.Value{
+				"access_token": tftypes.NewValue(tftypes.String, "token"),
+				"proxy_url":    tftypes.NewValue(tftypes.String, proxyURL),
+			})
+
+			if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Liara Proxy URL" {
+				t.Errorf("expected an invalid proxy URL diagnostic, got: %v", diags)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureIdleConnections(t *testing.T) {
+	testCases := []struct {
+		name                      string
+		attributes                map[string]tftypes.Value
+		expectMaxIdleConns        int
+		expectMaxIdleConnsPerHost int
+	}{
+		{
+			name:                      "defaults",
+			attributes:                map[string]tftypes.Value{},
+			expectMaxIdleConns:        int(defaultMaxIdleConns),
+			expectMaxIdleConnsPerHost: int(defaultMaxIdleConnsPerHost),
+		},
+		{
+			name: "configured",
+			attributes: map[string]tftypes.Value{
+				"max_idle_conns":          tftypes.NewValue(tftypes.Number, 50),
+				"max_idle_conns_per_host": tftypes.NewValue(tftypes.Number, 25),
+			},
+			expectMaxIdleConns:        50,
+			expectMaxIdleConnsPerHost: 25,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.attributes["access_token"] = tftypes.NewValue(tftypes.String, "token")
+
+			providerData, diags := testProviderConfigure(t, tc.attributes)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			retry, ok := providerData.HTTPClient.Transport.(*retryTransport)
+			if !ok {
+				t.Fatalf("expected the retry transport, got %T", providerData.HTTPClient.Transport)
+			}
+
+			transport, ok := retry.next.(*http.Transport)
+			if !ok {
+				t.Fatalf("expected the retry transport to wrap an *http.Transport, got %T", retry.next)
+			}
+
+			if transport.MaxIdleConns != tc.expectMaxIdleConns {
+				t.Errorf("expected MaxIdleConns %d, got %d", tc.expectMaxIdleConns, transport.MaxIdleConns)
+			}
+
+			if transport.MaxIdleConnsPerHost != tc.expectMaxIdleConnsPerHost {
+				t.Errorf("expected MaxIdleConnsPerHost %d, got %d", tc.expectMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		_, _ = w.Write([]byte(`{"projects":[]}`))
+	}))
+	defer server.Close()
+
+	providerData, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token":      tftypes.NewValue(tftypes.String, "token"),
+		"api_endpoint":      tftypes.NewValue(tftypes.String, server.URL),
+		"user_agent_suffix": tftypes.NewValue(tftypes.String, "ci/github-actions"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	ctx := context.Background()
+	d := &APIStatusDataSource{}
+
+	configureResp := datasource.ConfigureResponse{}
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	d.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if expected := "terraform-provider-liara/test (terraform-plugin-framework) ci/github-actions"; userAgent != expected {
+		t.Errorf("expected User-Agent %q, got %q", expected, userAgent)
+	}
+}
+
+func TestProviderConfigureDefaultHeaders(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"projects":[]}`))
+	}))
+	defer server.Close()
+
+	headersType := tftypes.Map{ElementType: tftypes.String}
+	providerData, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, "token"),
+		"api_endpoint": tftypes.NewValue(tftypes.String, server.URL),
+		"default_headers": tftypes.NewValue(headersType, map[string]tftypes.Value{
+			"X-Org-Id": tftypes.NewValue(tftypes.String, "42"),
+		}),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	response, err := providerData.PaasClient.GetApps(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeResponseBody(response.Body)
+
+	if orgID := headers.Get("X-Org-Id"); orgID != "42" {
+		t.Errorf("expected X-Org-Id 42, got %q", orgID)
+	}
+
+	if authorization := headers.Get("Authorization"); authorization != "Bearer token" {
+		t.Errorf("expected the Authorization of the access token, got %q", authorization)
+	}
+}
+
+func TestProviderDefaultHeadersValidation(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := provider.SchemaResponse{}
+	New("test")().Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	attribute, ok := schemaResp.Schema.Attributes["default_headers"].(schema.MapAttribute)
+	if !ok {
+		t.Fatalf("unexpected default_headers attribute type %T", schemaResp.Schema.Attributes["default_headers"])
+	}
+
+	testCases := []struct {
+		name         string
+		header       string
+		expectErrors int
+	}{
+		{name: "valid", header: "X-Org-Id"},
+		{name: "space", header: "X Org Id", expectErrors: 1},
+		{name: "authorization", header: "authorization", expectErrors: 1},
+		{name: "user agent", header: "User-Agent", expectErrors: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.MapRequest{
+				Path: path.Root("default_headers"),
+				ConfigValue: types.MapValueMust(types.StringType, map[string]attr.Value{
+					tc.header: types.StringValue("value"),
+				}),
+			}
+			resp := validator.MapResponse{}
+
+			for _, v := range attribute.Validators {
+				v.ValidateMap(ctx, req, &resp)
+			}
+
+			if resp.Diagnostics.ErrorsCount() != tc.expectErrors {
+				t.Errorf("expected %d errors, got: %v", tc.expectErrors, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureSharesClients(t *testing.T) {
+	providerData, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, "token"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	ctx := context.Background()
+	r := &AppResource{}
+	d := &AppsDataSource{}
+
+	resourceResp := resource.ConfigureResponse{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: providerData}, &resourceResp)
+	dataSourceResp := datasource.ConfigureResponse{}
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData}, &dataSourceResp)
+	if resourceResp.Diagnostics.HasError() || dataSourceResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v %v", resourceResp.Diagnostics, dataSourceResp.Diagnostics)
+	}
+
+	if r.client == nil || r.client != providerData.PaasClient || d.client != providerData.PaasClient {
+		t.Error("expected the resource and the data source to share the PAAS client of the provider")
+	}
+
+	// the file browser endpoint has no default.
+	if providerData.FileBrowserClient != nil {
+		t.Errorf("expected no file browser client without an endpoint, got %v", providerData.FileBrowserClient)
+	}
+}
+
+func TestProviderConfigureBasePath(t *testing.T) {
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"projects":[]}`))
+	}))
+	defer server.Close()
+
+	providerData, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, "token"),
+		"api_endpoint": tftypes.NewValue(tftypes.String, server.URL+"/"),
+		"base_path":    tftypes.NewValue(tftypes.String, "/api/v1/"),
+		"dns_endpoint": tftypes.NewValue(tftypes.String, "https://dns.example.com"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if expected := defaultWebsocketEndpoint + "/api/v1"; providerData.WebsocketEndpoint != expected {
+		t.Errorf("expected websocket endpoint %s, got %s", expected, providerData.WebsocketEndpoint)
+	}
+
+	// the other endpoints aren't prefixed.
+	if expected := "https://dns.example.com"; providerData.DNSEndpoint != expected {
+		t.Errorf("expected dns endpoint %s, got %s", expected, providerData.DNSEndpoint)
+	}
+
+	ctx := context.Background()
+	d := &APIStatusDataSource{}
+
+	configureResp := datasource.ConfigureResponse{}
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	d.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if expected := "/api/v1/v1/projects"; requestPath != expected {
+		t.Errorf("expected request path %s, got %s", expected, requestPath)
+	}
+}
+
+func TestProviderConfigureEmptyWebsocketEndpoint(t *testing.T) {
+	providerData, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token":       tftypes.NewValue(tftypes.String, "token"),
+		"websocket_endpoint": tftypes.NewValue(tftypes.String, ""),
+		"base_path":          tftypes.NewValue(tftypes.String, "/api/v1"),
+	})
+
+	// only the data sources which use the websocket need the endpoint.
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if providerData.WebsocketEndpoint != "" {
+		t.Errorf("expected an empty websocket endpoint, got %s", providerData.WebsocketEndpoint)
+	}
+}
+
+func TestProviderConfigureInvalidBasePath(t *testing.T) {
+	_, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, "token"),
+		"base_path":    tftypes.NewValue(tftypes.String, "api/v1"),
+	})
+
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Liara API Base Path" {
+		t.Errorf("expected an invalid base path error, got: %v", diags)
+	}
+}
+
+func TestProviderConfigureRegion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		attributes      map[string]tftypes.Value
+		env             map[string]string
+		expectAPI       string
+		expectWebsocket string
+	}{
+		{
+			name:            "default",
+			expectAPI:       defaultAPIEndpoint,
+			expectWebsocket: defaultWebsocketEndpoint,
+		},
+		{
+			name:            "iran",
+			attributes:      map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "iran")},
+			expectAPI:       "https://api.iran.liara.ir",
+			expectWebsocket: "wss://api.iran.liara.ir",
+		},
+		{
+			name:            "germany",
+			attributes:      map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "germany")},
+			expectAPI:       "https://api.liara.ir",
+			expectWebsocket: "wss://api.liara.ir",
+		},
+		{
+			name:            "environment variable",
+			env:             map[string]string{"LIARA_REGION": "germany"},
+			expectAPI:       "https://api.liara.ir",
+			expectWebsocket: "wss://api.liara.ir",
+		},
+		{
+			name: "explicit endpoint takes precedence",
+			attributes: map[string]tftypes.Value{
+				"region":       tftypes.NewValue(tftypes.String, "germany"),
+				"api_endpoint": tftypes.NewValue(tftypes.String, "https://api.example.com"),
+			},
+			expectAPI:       "https://api.example.com",
+			expectWebsocket: "wss://api.liara.ir",
+		},
+		{
+			name:            "endpoint environment variable takes precedence",
+			attributes:      map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "germany")},
+			env:             map[string]string{"LIARA_WEBSOCKET_ENDPOINT": "wss://ws.example.com"},
+			expectAPI:       "https://api.liara.ir",
+			expectWebsocket: "wss://ws.example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"LIARA_REGION", "LIARA_API_ENDPOINT", "LIARA_WEBSOCKET_ENDPOINT"} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			attributes := map[string]tftypes.Value{
+				"access_token": tftypes.NewValue(tftypes.String, "token"),
+			}
+			for key, value := range tc.attributes {
+				attributes[key] = value
+			}
+
+			providerData, diags := testProviderConfigure(t, attributes)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if providerData.APIEndpoint != tc.expectAPI {
+				t.Errorf("expected api endpoint %s, got %s", tc.expectAPI, providerData.APIEndpoint)
+			}
+
+			if providerData.WebsocketEndpoint != tc.expectWebsocket {
+				t.Errorf("expected websocket endpoint %s, got %s", tc.expectWebsocket, providerData.WebsocketEndpoint)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureInvalidRegion(t *testing.T) {
+	t.Setenv("LIARA_REGION", "mars")
+
+	_, diags := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, "token"),
+	})
+
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Liara Region" {
+		t.Errorf("expected an invalid region diagnostic, got: %v", diags)
+	}
+}
+
+// testProviderConfigure configures the provider with the given attributes and
+// returns the data passed to resources and data sources.
+func testProviderConfigure(t *testing.T, attributes map[string]tftypes.Value) (*LiaraProviderData, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	schemaResp := provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    testObjectValue(schemaResp.Schema.Type().TerraformType(ctx), attributes),
+		},
+	}
+	resp := provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, &resp)
+
+	providerData, _ := resp.ResourceData.(*LiaraProviderData)
+
+	return providerData, resp.Diagnostics
+}
+
+// testObjectValue returns an object of the given type holding the given
+// attribute values, with all the other attributes set to null.
+func testObjectValue(typ tftypes.Type, attributes map[string]tftypes.Value) tftypes.Value {
+	objectType, ok := typ.(tftypes.Object)
+	if !ok {
+		return tftypes.NewValue(typ, nil)
+	}
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+			continue
+		}
+
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	return tftypes.NewValue(objectType, values)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestWithOperationTimeout(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cancel       bool
+		failed       bool
+		expectErrors []string
+	}{
+		{name: "completed at the deadline"},
+		{name: "failed at the deadline", failed: true, expectErrors: []string{"Request failed", "Operation timed out"}},
+		{name: "completed when cancelled", cancel: true},
+		{name: "failed when cancelled", cancel: true, failed: true, expectErrors: []string{"Request failed", "Operation cancelled"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parent, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var diagnostics diag.Diagnostics
+			ctx, done := withOperationTimeout(parent, time.Millisecond, &diagnostics)
+
+			if tc.cancel {
+				cancel()
+			}
+
+			// the operation ends right when the context is done.
+			<-ctx.Done()
+			if tc.failed {
+				diagnostics.AddError("Request failed", ctx.Err().Error())
+			}
+			done()
+
+			var summaries []string
+			for _, d := range diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+
+			if !slices.Equal(summaries, tc.expectErrors) {
+				t.Errorf("expected errors %v, got %v", tc.expectErrors, summaries)
+			}
+		})
+	}
+}
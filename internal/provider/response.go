@@ -0,0 +1,11 @@
+package provider
+
+import "io"
+
+// closeResponseBody drains and closes a response body. Bodies that aren't
+// read to the end keep the connection from being reused, so every response
+// is closed this way, whether it was decoded or not.
+func closeResponseBody(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, body)
+	_ = body.Close()
+}
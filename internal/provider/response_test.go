@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tarhche/liara-terraform-provider/openapi/clients/paas"
+)
+
+// drainedBodyPaasClient returns the app from a body it keeps track of.
+type drainedBodyPaasClient struct {
+	*fakePaasClient
+
+	body *trackingBody
+}
+
+func (c *drainedBodyPaasClient) GetAppByName(ctx context.Context, name string, reqEditors ...paas.RequestEditorFn) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       c.body,
+	}, nil
+}
+
+// trackingBody records whether it was read to the end and closed.
+type trackingBody struct {
+	*strings.Reader
+
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestAppResourceReadDrainsBody(t *testing.T) {
+	// the decoder stops after the JSON value, leaving the trailing
+	// whitespace unread.
+	client := &drainedBodyPaasClient{
+		fakePaasClient: &fakePaasClient{},
+		body: &trackingBody{
+			Reader: strings.NewReader(`{"project":{"_id":"id","project_id":"my-app","type":"docker","planID":"small","scale":1}}` + strings.Repeat("\n", 64)),
+		},
+	}
+
+	ctx := context.Background()
+	r := &AppResource{client: client}
+
+	state := testAppResourceState(ctx, t, r, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "my-app"),
+	})
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if remaining := client.body.Len(); remaining != 0 {
+		t.Errorf("expected the body to be drained, %d bytes are left", remaining)
+	}
+
+	if !client.body.closed {
+		t.Error("expected the body to be closed")
+	}
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRetryWait caps the wait between two attempts, both the backoff and the
+// Retry-After header sent by the API.
+const maxRetryWait = 60 * time.Second
+
+// retryTransport retries idempotent requests which failed with a transient
+// error (429 or 5xx), waiting with exponential backoff and jitter between the
+// attempts. A Retry-After header sent by the API takes precedence over the
+// computed backoff, up to maxWait.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int64
+	wait       time.Duration
+	maxWait    time.Duration
+	clock      clock
+}
+
+// newRetryTransport wraps next (or http.DefaultTransport when nil) with retries,
+// waiting between the attempts on the given clock (or the system clock when nil).
+// The timeout is the one of the client sending the requests, a wait is capped
+// at half of it so the retry still has time to complete.
+func newRetryTransport(next http.RoundTripper, maxRetries int64, wait time.Duration, timeout time.Duration, c clock) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if c == nil {
+		c = realClock{}
+	}
+
+	maxWait := maxRetryWait
+	if timeout > 0 {
+		maxWait = min(maxWait, timeout/2)
+	}
+
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		wait:       wait,
+		maxWait:    maxWait,
+		clock:      c,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdempotentRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	// the request of the caller must not be modified, so each retry sends a
+	// clone of it with a fresh body.
+	attemptReq := req
+	for attempt := int64(0); ; attempt++ {
+		response, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !isRetryableResponse(response, err) {
+			return response, err
+		}
+
+		wait := t.backoff(attempt)
+		if retryAfter, ok := retryAfterDuration(response, t.clock.Now()); ok {
+			wait = min(retryAfter, t.maxWait)
+		}
+
+		if response != nil {
+			closeResponseBody(response.Body)
+		}
+
+		if err := t.clock.Sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq.Body = body
+		}
+	}
+}
+
+// backoff returns the wait before the given retry attempt (zero based).
+func (t *retryTransport) backoff(attempt int64) time.Duration {
+	if t.wait <= 0 {
+		return 0
+	}
+
+	wait := t.wait << attempt
+	if wait <= 0 || wait > t.maxWait {
+		wait = t.maxWait
+	}
+
+	// add up to 50% jitter so concurrent requests don't retry in lockstep.
+	if half := int64(wait / 2); half > 0 {
+		wait += time.Duration(rand.Int63n(half))
+	}
+
+	return wait
+}
+
+// isIdempotentRequest reports whether the request can be safely sent again.
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	// the body must be rewindable to be sent again.
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// isRetryableResponse reports whether the request failed with a transient error.
+func isRetryableResponse(response *http.Response, err error) bool {
+	if err != nil {
+		return false
+	}
+
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+}
+
+// retryAfterDuration parses the Retry-After header, which is either a number
+// of seconds or an HTTP date, relative to now. The wait is capped at
+// maxRetryWait, so the API can't stall an apply for longer.
+func retryAfterDuration(response *http.Response, now time.Time) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+
+	value := response.Header.Get("Retry-After")
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		// larger values would overflow the duration.
+		wait = maxRetryWait
+		if seconds < int64(maxRetryWait/time.Second) {
+			wait = time.Duration(seconds) * time.Second
+		}
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = max(date.Sub(now), 0)
+	} else {
+		return 0, false
+	}
+
+	return min(wait, maxRetryWait), true
+}
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		maxRetries     int64
+		expectStatus   int
+		expectAttempts int
+	}{
+		{
+			name:           "retries until success",
+			method:         http.MethodGet,
+			maxRetries:     3,
+			expectStatus:   http.StatusOK,
+			expectAttempts: 3,
+		},
+		{
+			name:           "gives up after max retries",
+			method:         http.MethodGet,
+			maxRetries:     1,
+			expectStatus:   http.StatusServiceUnavailable,
+			expectAttempts: 2,
+		},
+		{
+			name:           "retries requests with a body",
+			method:         http.MethodPut,
+			maxRetries:     3,
+			expectStatus:   http.StatusOK,
+			expectAttempts: 3,
+		},
+		{
+			name:           "doesn't retry non-idempotent requests",
+			method:         http.MethodPost,
+			maxRetries:     3,
+			expectStatus:   http.StatusServiceUnavailable,
+			expectAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int
+			var bodies []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+
+				if attempts <= 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: newRetryTransport(nil, tc.maxRetries, time.Millisecond, 0, nil)}
+
+			req, err := http.NewRequest(tc.method, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			response, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			defer response.Body.Close()
+
+			if response.StatusCode != tc.expectStatus {
+				t.Errorf("expected status %d, got %d", tc.expectStatus, response.StatusCode)
+			}
+
+			if attempts != tc.expectAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.expectAttempts, attempts)
+			}
+
+			for i, body := range bodies {
+				if body != "payload" {
+					t.Errorf("attempt #%d: expected the request body to be resent, got %q", i+1, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfterDuration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		expectOK bool
+		expect   time.Duration
+	}{
+		{name: "missing", header: "", expectOK: false},
+		{name: "seconds", header: "5", expectOK: true, expect: 5 * time.Second},
+		{name: "seconds beyond the maximum", header: "3600", expectOK: true, expect: maxRetryWait},
+		{name: "seconds overflowing a duration", header: "9223372036854775807", expectOK: true, expect: maxRetryWait},
+		{name: "date in the past", header: "Mon, 02 Jan 2006 15:04:05 GMT", expectOK: true, expect: 0},
+		{name: "date beyond the maximum", header: "Fri, 31 Dec 9999 23:59:59 GMT", expectOK: true, expect: maxRetryWait},
+		{name: "invalid", header: "soon", expectOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if len(tc.header) > 0 {
+				response.Header.Set("Retry-After", tc.header)
+			}
+
+			wait, ok := retryAfterDuration(response, time.Now())
+			if ok != tc.expectOK {
+				t.Fatalf("expected ok %t, got %t", tc.expectOK, ok)
+			}
+
+			if wait != tc.expect {
+				t.Errorf("expected wait %s, got %s", tc.expect, wait)
+			}
+		})
+	}
+}
+
+// roundTripperFunc is a http.RoundTripper calling the function.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransportKeepsRequest(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req)
+
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+
+		status := http.StatusServiceUnavailable
+		if len(requests) == 3 {
+			status = http.StatusOK
+		}
+
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: http.NoBody}, nil
+	})
+
+	req, err := http.NewRequest(http.MethodPut, "http://example.com", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := req.Body
+
+	response, err := newRetryTransport(next, 3, time.Millisecond, 0, &fakeClock{}).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	response.Body.Close()
+
+	if req.Body != body {
+		t.Error("expected the body of the request not to be replaced")
+	}
+
+	if len(requests) != 3 || requests[1] == req || requests[2] == req || requests[1] == requests[2] {
+		t.Errorf("expected each retry to send a new request, got %d requests", len(requests))
+	}
+
+	for i, body := range bodies {
+		if body != "payload" {
+			t.Errorf("attempt #%d: expected the request body to be resent, got %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransportWaitCappedByTimeout(t *testing.T) {
+	testCases := []struct {
+		name       string
+		retryAfter string
+		timeout    time.Duration
+		expectMin  time.Duration
+		expectMax  time.Duration
+	}{
+		{name: "retry after", retryAfter: "3600", timeout: 10 * time.Second, expectMin: 5 * time.Second, expectMax: 5 * time.Second},
+		{name: "retry after without timeout", retryAfter: "3600", expectMin: maxRetryWait, expectMax: maxRetryWait},
+		{name: "retry after below the cap", retryAfter: "2", timeout: 10 * time.Second, expectMin: 2 * time.Second, expectMax: 2 * time.Second},
+		{name: "backoff", timeout: 10 * time.Second, expectMin: 5 * time.Second, expectMax: 7500 * time.Millisecond},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				response := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: http.NoBody}
+				if len(tc.retryAfter) > 0 {
+					response.Header.Set("Retry-After", tc.retryAfter)
+				}
+
+				return response, nil
+			})
+
+			req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := &fakeClock{now: time.Now()}
+			response, err := newRetryTransport(next, 1, 20*time.Second, tc.timeout, c).RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			response.Body.Close()
+
+			waits := c.Waits()
+			if len(waits) != 1 || waits[0] < tc.expectMin || waits[0] > tc.expectMax {
+				t.Errorf("expected a wait between %s and %s, got %v", tc.expectMin, tc.expectMax, waits)
+			}
+		})
+	}
+}
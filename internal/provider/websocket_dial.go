@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+	"golang.org/x/net/websocket"
+)
+
+// dialWebsocket opens a websocket connection with the TLS configuration and
+// the proxy of the transport (or http.DefaultTransport when nil), so it
+// connects wherever the API requests do.
+func dialWebsocket(ctx context.Context, config *websocket.Config, transport *http.Transport) (*websocket.Conn, error) {
+	if transport == nil {
+		defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			defaultTransport = &http.Transport{}
+		}
+		transport = defaultTransport
+	}
+
+	location := config.Location
+	conn, err := dialThroughProxy(ctx, location, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	// the handshakes don't take a context, so the deadline of the context
+	// bounds them instead.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if location.Scheme == "wss" {
+		tlsConn := tls.Client(conn, websocketTLSConfig(transport, location.Hostname()))
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	return ws, nil
+}
+
+// dialThroughProxy opens a TCP connection to the websocket location, through
+// the proxy the transport picks for it.
+func dialThroughProxy(ctx context.Context, location *url.URL, transport *http.Transport) (net.Conn, error) {
+	dial := transport.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	address := hostPort(location)
+
+	// the proxy is picked as for the HTTP request the websocket upgrades
+	// from.
+	var proxyURL *url.URL
+	if transport.Proxy != nil {
+		requestURL := *location
+		requestURL.Scheme = map[string]string{"ws": "http", "wss": "https"}[location.Scheme]
+
+		var err error
+		if proxyURL, err = transport.Proxy(&http.Request{Method: http.MethodGet, URL: &requestURL, Header: http.Header{}}); err != nil {
+			return nil, err
+		}
+	}
+
+	if proxyURL == nil {
+		return dial(ctx, "tcp", address)
+	}
+
+	switch proxyURL.Scheme {
+	case "socks5":
+		var auth *proxy.Auth
+		if proxyURL.User != nil {
+			password, _ := proxyURL.User.Password()
+			auth = &proxy.Auth{User: proxyURL.User.Username(), Password: password}
+		}
+
+		dialer, err := proxy.SOCKS5("tcp", hostPort(proxyURL), auth, &net.Dialer{})
+		if err != nil {
+			return nil, err
+		}
+
+		contextDialer, ok := dialer.(proxy.ContextDialer)
+		if !ok {
+			return dialer.Dial("tcp", address)
+		}
+
+		return contextDialer.DialContext(ctx, "tcp", address)
+	case "http", "https":
+		return dialHTTPProxy(ctx, dial, proxyURL, address, transport)
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+}
+
+// dialHTTPProxy opens a tunnel to the address through an HTTP proxy.
+func dialHTTPProxy(ctx context.Context, dial func(ctx context.Context, network string, address string) (net.Conn, error), proxyURL *url.URL, address string, transport *http.Transport) (net.Conn, error) {
+	conn, err := dial(ctx, "tcp", hostPort(proxyURL))
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if proxyURL.Scheme == "https" {
+		tlsConn := tls.Client(conn, websocketTLSConfig(transport, proxyURL.Hostname()))
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	connectReq := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: transport.ProxyConnectHeader.Clone(),
+	}
+	if connectReq.Header == nil {
+		connectReq.Header = http.Header{}
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		connectReq.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if err := connectReq.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	// the server doesn't send anything before the client speaks, so nothing
+	// past the response is buffered.
+	response, err := http.ReadResponse(bufio.NewReader(conn), connectReq)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	// the body of an established tunnel is the tunneled connection, so it is
+	// only closed when the proxy refused it.
+	if response.StatusCode != http.StatusOK {
+		_ = response.Body.Close()
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy refused the connection to %s: %s", address, response.Status)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	return conn, nil
+}
+
+// websocketTLSConfig returns the TLS configuration of the transport for a
+// connection to the server.
+func websocketTLSConfig(transport *http.Transport, serverName string) *tls.Config {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if transport.TLSClientConfig != nil {
+		config = transport.TLSClientConfig.Clone()
+	}
+
+	if len(config.ServerName) == 0 {
+		config.ServerName = serverName
+	}
+
+	return config
+}
+
+// hostPort returns the host and the port of the URL, defaulting the port
+// by the scheme.
+func hostPort(u *url.URL) string {
+	if port := u.Port(); len(port) > 0 {
+		return net.JoinHostPort(u.Hostname(), port)
+	}
+
+	port := "80"
+	switch u.Scheme {
+	case "https", "wss":
+		port = "443"
+	case "socks5":
+		port = "1080"
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestDialWebsocketTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(websocket.Handler(func(conn *websocket.Conn) {
+		_ = websocket.Message.Send(conn, "hello")
+	}))
+	defer server.Close()
+
+	// the rejected handshake is logged by the server otherwise.
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	location := "wss" + strings.TrimPrefix(server.URL, "https")
+
+	t.Run("trusted by the transport", func(t *testing.T) {
+		transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}
+
+		if message := testDialWebsocket(t, location, server.URL, transport); message != "hello" {
+			t.Errorf("expected hello, got %q", message)
+		}
+	})
+
+	t.Run("untrusted", func(t *testing.T) {
+		config, err := websocket.NewConfig(location, server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := dialWebsocket(context.Background(), config, &http.Transport{}); err == nil {
+			t.Error("expected the unknown certificate authority to be rejected")
+		}
+	})
+}
+
+func TestDialWebsocketHTTPProxy(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		_ = websocket.Message.Send(conn, "hello")
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var tunneledTo, proxyAuthorization string
+
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		mu.Lock()
+		tunneledTo = r.Host
+		proxyAuthorization = r.Header.Get("Proxy-Authorization")
+		mu.Unlock()
+
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer upstream.Close()
+
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+
+		go func() { _, _ = io.Copy(upstream, conn) }()
+		_, _ = io.Copy(conn, upstream)
+	}))
+	defer proxyServer.Close()
+
+	proxyURL, err := url.Parse(proxyServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyURL.User = url.UserPassword("user", "secret")
+
+	transport := &http.Transport{Proxy: http.ProxyURL(proxyURL)}
+	location := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	if message := testDialWebsocket(t, location, server.URL, transport); message != "hello" {
+		t.Errorf("expected hello, got %q", message)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if tunneledTo != strings.TrimPrefix(server.URL, "http://") {
+		t.Errorf("expected a tunnel to the websocket server, got %q", tunneledTo)
+	}
+
+	if proxyAuthorization != "Basic dXNlcjpzZWNyZXQ=" {
+		t.Errorf("expected the proxy credentials to be sent, got %q", proxyAuthorization)
+	}
+}
+
+// testDialWebsocket dials the websocket and returns the first message it
+// receives.
+func testDialWebsocket(t *testing.T, location string, origin string, transport *http.Transport) string {
+	t.Helper()
+
+	config, err := websocket.NewConfig(location, origin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := dialWebsocket(context.Background(), config, transport)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer conn.Close()
+
+	var message string
+	if err := websocket.Message.Receive(conn, &message); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return message
+}